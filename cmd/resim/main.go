@@ -0,0 +1,58 @@
+// Resim simulates a recorded match again without a window
+// and checks that it goes the same way as recorded.
+// It is used to reproduce gameplay bugs and to catch
+// determinism regressions.
+//
+// Usage:
+//
+//	resim [replay file]
+//
+// The latest replay from RECORDINGS_DIR is used if the file is not set.
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"online_shooter/internal/config"
+	"online_shooter/internal/replay"
+	"online_shooter/internal/replay/resim"
+	"os"
+)
+
+func main() {
+	flag.Parse()
+
+	// choose the replay
+	path := flag.Arg(0)
+	if path == "" {
+		path = replay.Latest(config.RecordingsDir())
+		if path == "" {
+			fmt.Fprintln(os.Stderr, "no replay to simulate in", config.RecordingsDir())
+			os.Exit(2)
+		}
+	}
+
+	r, err := replay.Load(path)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "failed to load the replay:", err)
+		os.Exit(2)
+	}
+
+	// simulate the match with the recorded configuration
+	config.SetGameValues(r.Header.Config)
+	result, err := resim.Run(r)
+
+	var mismatch *resim.MismatchError
+	if errors.As(err, &mismatch) {
+		fmt.Fprintf(os.Stderr, "%s: the simulated match differs from the recorded one\n%v\n", path, err)
+		os.Exit(1)
+	}
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "failed to simulate the match:", err)
+		os.Exit(2)
+	}
+
+	fmt.Printf("%s: %d ticks simulated, %d frames match (seed %d)\n",
+		path, result.Ticks, result.Frames, r.Header.Seed)
+}
@@ -6,6 +6,8 @@ import (
 	"online_shooter/internal/config"
 	"online_shooter/internal/game/game"
 	"online_shooter/internal/menu"
+	"online_shooter/internal/netstats"
+	"online_shooter/internal/replay"
 	"online_shooter/internal/server"
 )
 
@@ -22,6 +24,14 @@ type App struct {
 	menu         *menu.Menu
 	server       *server.Server
 	conn         *websocket.Conn
+	netStats     *netstats.Stats
+	showNetGraph bool
+	showScores   bool
+
+	// replay is nil if the replay is not watched
+	replay           *replay.Player
+	replayFollow     bool
+	replayFollowedId int64
 }
 
 func Run() error {
@@ -30,9 +40,16 @@ func Run() error {
 		screenHeight: config.ScreenHeight(),
 		game:         &game.Game{},
 		menu:         menu.NewMenu(),
+		netStats:     &netstats.Stats{},
 	}
 	ebiten.SetWindowSize(int(app.screenWidth), int(app.screenHeight))
 	ebiten.SetWindowTitle("Shooter")
 	err := ebiten.RunGame(app)
+
+	// stop the server to finish the match recording
+	if app.server != nil {
+		app.server.Close()
+	}
+
 	return err
 }
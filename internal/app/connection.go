@@ -1,47 +1,96 @@
 package app
 
 import (
+	"crypto/tls"
 	"encoding/json"
 	"fmt"
 	"github.com/gorilla/websocket"
 	"net/http"
+	"online_shooter/internal/config"
 	"online_shooter/internal/logger"
 	"online_shooter/internal/model"
 	"online_shooter/internal/server"
+	"online_shooter/internal/tlsutil"
 )
 
 // connectWithServer creates a new player on the server and
 // establishes WebSocket connection with the server to communicate
 // with it.
 func (a *App) connectWithServer() {
+	// create a tls configuration for the secure connection
+	tlsConfig, err := a.createTLSConfig()
+	if err != nil {
+		logger.Warn("error while creating tls configuration: ", err)
+		return
+	}
+
 	// create a new player
-	err := a.createPlayer()
+	err = a.createPlayer(tlsConfig)
 	if err != nil {
 		logger.Warn("error while creating player on server: ", err)
 		return
 	}
 
 	// get a websocket connection with the server
-	err = a.setupWebSocketConnection()
+	err = a.setupWebSocketConnection(tlsConfig)
 	if err != nil {
 		logger.Warn("error while creating websocket connection with server: ", err)
 		return
 	}
 }
 
+// createTLSConfig creates a tls configuration for the client
+// if the secure connection is required. The client trusts
+// the system certificates, certificates from the configured file
+// and the certificate of the server started by the application.
+//
+// Returns a pointer to the tls configuration or nil if
+// the connection is not secure and an error if it exists,
+// otherwise nil.
+func (a *App) createTLSConfig() (*tls.Config, error) {
+	if !a.menu.Secure {
+		return nil, nil
+	}
+
+	// trust the certificate of the local server
+	var localCertificate []byte
+	if a.server != nil {
+		localCertificate = a.server.Certificate()
+	}
+
+	return tlsutil.ClientConfig(config.TLSCAFile(), config.TLSInsecureSkipVerify(), localCertificate)
+}
+
 // createPlayer creates a new player
 // on the server and gets its id.
 //
+// Accepts a pointer to the tls configuration
+// which is nil if the connection is not secure.
+//
 // Returns an error if the creation fails.
-func (a *App) createPlayer() error {
+func (a *App) createPlayer(tlsConfig *tls.Config) error {
+	// choose the scheme
+	scheme := "http"
+	if tlsConfig != nil {
+		scheme = "https"
+	}
+
 	// make the request
-	url := fmt.Sprintf("http://%s%s",
+	url := fmt.Sprintf("%s://%s%s",
+		scheme,
 		a.menu.ConnectionAddress,
 		server.CreatePlayerPostfix)
-	resp, err := http.Post(url, "", nil)
+	client := &http.Client{Transport: &http.Transport{TLSClientConfig: tlsConfig}}
+	resp, err := client.Post(url, "", nil)
 	if err != nil {
 		return err
 	}
+	defer resp.Body.Close()
+
+	// check if the player is created
+	if resp.StatusCode != http.StatusCreated {
+		return fmt.Errorf("failed to create a player: %s", resp.Status)
+	}
 
 	// decode a createPlayerResponse
 	var createPlayerResponse model.CreatePlayerResponse
@@ -60,14 +109,28 @@ func (a *App) createPlayer() error {
 // setupWebSocketConnection establishes a WebSocket connection to the server.
 // It uses the IP, port, and connection endpoint provided in the app's menu configuration.
 //
+// Accepts a pointer to the tls configuration
+// which is nil if the connection is not secure.
+//
 // Returns an error if the connection fails.
-func (a *App) setupWebSocketConnection() error {
+func (a *App) setupWebSocketConnection(tlsConfig *tls.Config) error {
 	// create a WebSocket dialer to initiate the connection
-	dialer := websocket.Dialer{}
+	dialer := websocket.Dialer{TLSClientConfig: tlsConfig}
+
+	// choose the scheme
+	scheme := "ws"
+	if tlsConfig != nil {
+		scheme = "wss"
+	}
 
 	// construct the WebSocket URL using the IP, port, and connection endpoint
-	endpoint := fmt.Sprintf("%s%d", server.ConnectPlayerPostfix, a.game.Player.Id)
-	wsURL := fmt.Sprintf("ws://%s%s",
+	// pass the screen size so the server sends everything the camera shows
+	endpoint := fmt.Sprintf("%s%d?%s=%d&%s=%d",
+		server.ConnectPlayerPostfix, a.game.Player.Id,
+		server.ViewportWidthParam, int(a.screenWidth),
+		server.ViewportHeightParam, int(a.screenHeight))
+	wsURL := fmt.Sprintf("%s://%s%s",
+		scheme,
 		a.menu.ConnectionAddress,
 		endpoint)
 
@@ -2,6 +2,7 @@ package app
 
 import (
 	"github.com/hajimehoshi/ebiten/v2"
+	"online_shooter/internal/game/drawer"
 )
 
 // Draw draws the game screen by one frame.
@@ -18,6 +19,25 @@ func (a *App) Draw(screen *ebiten.Image) {
 
 	// draw game if it is required
 	if a.game.Active {
-		a.game.Draw(screen)
+		drawer.DrawGame(a.game, screen)
+
+		// draw the replay controls if the replay is watched
+		if a.replay != nil {
+			drawer.DrawReplayHUD(a.replay, a.replayFollow, screen)
+			return
+		}
+
+		// draw the state of the match
+		drawer.DrawGameMatch(a.game, screen)
+
+		// draw the scoreboard if it is required
+		if a.showScores {
+			drawer.DrawGameScoreboard(a.game, screen)
+		}
+
+		// draw the net graph if it is required
+		if a.showNetGraph {
+			drawer.DrawNetGraph(a.netStats.Summary(), screen)
+		}
 	}
 }
@@ -0,0 +1,123 @@
+package app
+
+import (
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/inpututil"
+	"online_shooter/internal/game/camera"
+	"online_shooter/internal/game/entity"
+	"online_shooter/internal/game/geometry"
+)
+
+type movement struct {
+	LeftKeyPressed  bool
+	UpKeyPressed    bool
+	RightKeyPressed bool
+	DownKeyPressed  bool
+}
+
+type shooting struct {
+	Shot bool
+	Aim  geometry.Point
+}
+
+// getPlayerMovement registers player's moving in case
+// keys are pressed.
+//
+// Returns a pointer to a struct containing all player movements.
+func getPlayerMovement() *movement {
+	m := &movement{}
+
+	// change update player data depending on pressed keys
+	if ebiten.IsKeyPressed(ebiten.KeyUp) || ebiten.IsKeyPressed(ebiten.KeyW) {
+		m.UpKeyPressed = true
+	}
+	if ebiten.IsKeyPressed(ebiten.KeyDown) || ebiten.IsKeyPressed(ebiten.KeyS) {
+		m.DownKeyPressed = true
+	}
+	if ebiten.IsKeyPressed(ebiten.KeyRight) || ebiten.IsKeyPressed(ebiten.KeyD) {
+		m.RightKeyPressed = true
+	}
+	if ebiten.IsKeyPressed(ebiten.KeyLeft) || ebiten.IsKeyPressed(ebiten.KeyA) {
+		m.LeftKeyPressed = true
+	}
+
+	return m
+}
+
+// getPlayerShooting gets the point to shoot toward
+// and tries to make a shot in case
+// LBM is pressed.
+//
+// Accepts a pointer to the camera object to recount
+// the mouse coordinates due to the world coordinate system.
+//
+// Returns a pointer to a struct containing info about a player's shot.
+func getPlayerShooting(camera *camera.Camera) *shooting {
+	s := &shooting{}
+
+	// check if lbm is pressed
+	if ebiten.IsMouseButtonPressed(ebiten.MouseButtonLeft) {
+		// get cursor position
+		x, y := ebiten.CursorPosition()
+		towards := geometry.Point{
+			X: float32(x),
+			Y: float32(y),
+		}
+
+		// recount the point to shoot
+		// to the world coordinate system
+		towards = camera.ScreenToWorld(towards)
+
+		// set the shot flog to true for server update
+		s.Shot = true
+
+		// set the aim for server update
+		s.Aim = towards
+	}
+
+	return s
+}
+
+// getWeaponSwitch gets the weapon the player switches to
+// by the number keys or the mouse wheel.
+//
+// Accepts a pointer to the player's square to switch
+// to the next or previous weapon by the wheel.
+//
+// Returns the slot of the weapon starting from one
+// or zero if the weapon isn't switched.
+func getWeaponSwitch(player *entity.Square) int {
+	// check the number keys of the slots
+	for i := 0; i < entity.PlayerWeapons; i++ {
+		if inpututil.IsKeyJustPressed(ebiten.Key1 + ebiten.Key(i)) {
+			return i + 1
+		}
+	}
+
+	if player == nil {
+		return 0
+	}
+
+	// scroll through the weapons by the wheel
+	_, dy := ebiten.Wheel()
+	current := int(player.WeaponKind)
+	switch {
+	case dy < 0:
+		return (current+1)%entity.PlayerWeapons + 1
+	case dy > 0:
+		return (current+entity.PlayerWeapons-1)%entity.PlayerWeapons + 1
+	}
+
+	return 0
+}
+
+// getPlayerReload checks if the player reloads
+// the weapon by the R key.
+func getPlayerReload() bool {
+	return inpututil.IsKeyJustPressed(ebiten.KeyR)
+}
+
+// getPlayerDash checks if the player dashes by the Shift key.
+func getPlayerDash() bool {
+	return inpututil.IsKeyJustPressed(ebiten.KeyShiftLeft) || inpututil.IsKeyJustPressed(ebiten.KeyShiftRight)
+}
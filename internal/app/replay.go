@@ -0,0 +1,204 @@
+package app
+
+import (
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/inpututil"
+	"online_shooter/internal/game/camera"
+	"online_shooter/internal/game/entity"
+	"online_shooter/internal/game/game"
+	"online_shooter/internal/game/geometry"
+	"online_shooter/internal/logger"
+	"online_shooter/internal/menu"
+	"online_shooter/internal/replay"
+	"sort"
+	"time"
+)
+
+const (
+	replaySeekStep    = 5 * time.Second
+	replayCameraSpeed = 600
+	replaySpeedFactor = 2
+)
+
+// watchReplay loads the replay file chosen in the menu
+// and starts playing it back. If the file can't be loaded
+// the menu is shown again.
+func (a *App) watchReplay() {
+	r, err := replay.Load(a.menu.ReplayPath)
+	if err != nil {
+		logger.Warn("failed to load the replay: ", err)
+		a.menu.Active = true
+		return
+	}
+
+	// init the game to draw the replay
+	a.game = &game.Game{
+		Arena:   r.Header.Arena,
+		Squares: make(map[int64]*entity.Square),
+		Active:  true,
+	}
+	a.game.Camera = camera.NewCamera(a.game.Arena.Width, a.game.Arena.Height)
+
+	a.replay = replay.NewPlayer(r)
+	a.replayFollow = true
+	a.replayFollowedId = 0
+
+	a.applyReplayFrame()
+}
+
+// updateReplay reads the replay controls, advances
+// the playback and applies the current frame to the game.
+func (a *App) updateReplay() {
+	// leave the replay
+	if inpututil.IsKeyJustPressed(ebiten.KeyEscape) {
+		a.stopReplay()
+		return
+	}
+
+	// pause or resume the playback
+	if inpututil.IsKeyJustPressed(ebiten.KeySpace) {
+		// restart the finished replay
+		if a.replay.Paused && a.replay.Position == a.replay.Replay.Duration() {
+			a.replay.Seek(0)
+		}
+		a.replay.Paused = !a.replay.Paused
+	}
+
+	// seek the playback
+	if inpututil.IsKeyJustPressed(ebiten.KeyArrowLeft) {
+		a.replay.Seek(a.replay.Position - replaySeekStep)
+	}
+	if inpututil.IsKeyJustPressed(ebiten.KeyArrowRight) {
+		a.replay.Seek(a.replay.Position + replaySeekStep)
+	}
+
+	// change the playback speed
+	if inpututil.IsKeyJustPressed(ebiten.KeyArrowUp) {
+		a.replay.SetSpeed(a.replay.Speed * replaySpeedFactor)
+	}
+	if inpututil.IsKeyJustPressed(ebiten.KeyArrowDown) {
+		a.replay.SetSpeed(a.replay.Speed / replaySpeedFactor)
+	}
+
+	// switch the camera mode
+	if inpututil.IsKeyJustPressed(ebiten.KeyC) {
+		a.replayFollow = !a.replayFollow
+	}
+
+	// choose the followed square
+	if inpututil.IsKeyJustPressed(ebiten.KeyQ) {
+		a.followNextSquare(-1)
+	}
+	if inpututil.IsKeyJustPressed(ebiten.KeyE) {
+		a.followNextSquare(1)
+	}
+
+	// advance the playback by one tick
+	a.replay.Advance(time.Second / time.Duration(ebiten.TPS()))
+	a.applyReplayFrame()
+
+	// move the free camera
+	if !a.replayFollow {
+		a.moveFreeCamera()
+	}
+}
+
+// applyReplayFrame changes the game state to the
+// current frame of the replay and moves the camera
+// to the followed square.
+func (a *App) applyReplayFrame() {
+	frame := a.replay.Frame()
+
+	a.game.GameMutex.Lock()
+	defer a.game.GameMutex.Unlock()
+
+	a.game.Squares = frame.Squares
+	a.game.Arena.Obstacles = frame.Obstacles
+
+	// follow the first square if the followed one is not in the game
+	a.game.Player = a.game.Squares[a.replayFollowedId]
+	if a.game.Player == nil {
+		a.followNextSquareLocked(0)
+	}
+
+	if a.replayFollow && a.game.Player != nil {
+		a.game.Camera.Move(a.game.Player.Position)
+	}
+}
+
+// followNextSquare switches the followed square.
+//
+// Accepts the direction of switching in the list of
+// the squares sorted by id.
+func (a *App) followNextSquare(direction int) {
+	a.game.GameMutex.Lock()
+	defer a.game.GameMutex.Unlock()
+
+	a.followNextSquareLocked(direction)
+}
+
+// followNextSquareLocked switches the followed square.
+// The game mutex must be locked by the caller.
+//
+// Accepts the direction of switching in the list of
+// the squares sorted by id, 0 chooses the first square.
+func (a *App) followNextSquareLocked(direction int) {
+	ids := make([]int64, 0, len(a.game.Squares))
+	for id := range a.game.Squares {
+		ids = append(ids, id)
+	}
+	if len(ids) == 0 {
+		a.game.Player = nil
+		return
+	}
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+
+	// find the followed square
+	index := 0
+	if direction != 0 {
+		for i, id := range ids {
+			if id == a.replayFollowedId {
+				index = (i + direction + len(ids)) % len(ids)
+				break
+			}
+		}
+	}
+
+	a.replayFollowedId = ids[index]
+	a.game.Player = a.game.Squares[a.replayFollowedId]
+}
+
+// moveFreeCamera moves the camera using the keys.
+func (a *App) moveFreeCamera() {
+	a.game.GameMutex.Lock()
+	defer a.game.GameMutex.Unlock()
+
+	vector := geometry.Vector{}
+	if ebiten.IsKeyPressed(ebiten.KeyW) {
+		vector.Y--
+	}
+	if ebiten.IsKeyPressed(ebiten.KeyS) {
+		vector.Y++
+	}
+	if ebiten.IsKeyPressed(ebiten.KeyA) {
+		vector.X--
+	}
+	if ebiten.IsKeyPressed(ebiten.KeyD) {
+		vector.X++
+	}
+
+	// move the camera with the constant speed
+	vector.Normalize()
+	step := replayCameraSpeed / float32(ebiten.TPS())
+	vector.X *= step
+	vector.Y *= step
+	a.game.Camera.Translate(vector)
+}
+
+// stopReplay stops the playback and shows the main menu.
+func (a *App) stopReplay() {
+	a.replay = nil
+	a.game = &game.Game{}
+	a.menu.State = menu.MainMenuState
+	a.menu.Active = true
+}
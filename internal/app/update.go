@@ -3,14 +3,20 @@ package app
 import (
 	"encoding/json"
 	"github.com/gorilla/websocket"
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/inpututil"
 	"online_shooter/internal/event"
 	"online_shooter/internal/game/game"
 	"online_shooter/internal/logger"
 	"online_shooter/internal/model"
+	"online_shooter/internal/netstats"
 	"online_shooter/internal/server"
+	"online_shooter/internal/tlsutil"
 	"time"
 )
 
+const pingInterval = time.Second
+
 // Update updates the application by one tick.
 //
 // Update updates only the game logic and Draw draws the screen.
@@ -30,28 +36,61 @@ func (a *App) Update() error {
 		// if it is a Start Server event
 		case event.EventStartServer:
 			// init the server
-			a.server = &server.Server{}
+			s, err := server.NewServer(&a.menu.ServerSettings)
+			if err != nil {
+				// stay in the settings to change them
+				logger.Warn("failed to start the server: ", err)
+				a.menu.ShowError("Failed to start the server: " + err.Error())
+				return nil
+			}
+			a.server = s
 
 			// start the server
-			go a.server.Run(&a.menu.ServerSettings)
+			go a.server.Run()
 
 			// set the connection address
 			a.menu.ConnectionAddress = server.PrivateInterface
 
+			// use the secure connection if the server requires it
+			a.menu.Secure = a.menu.TLSMode != tlsutil.ModeOff
+
 			// run the client game
 			a.runGame()
+
+		// if it is a Watch Replay event
+		case event.EventWatchReplay:
+			a.watchReplay()
 		}
 	}
 
+	// update the replay if it is watched
+	if a.replay != nil {
+		a.updateReplay()
+		return nil
+	}
+
 	// update the game if it is required
 	if a.game.Active {
+		// toggle the net graph
+		if inpututil.IsKeyJustPressed(ebiten.KeyF3) {
+			a.showNetGraph = !a.showNetGraph
+		}
+
 		a.game.GameMutex.RLock()
 
+		// show the scoreboard while tab is pressed
+		// and the final one after the round
+		a.showScores = ebiten.IsKeyPressed(ebiten.KeyTab) ||
+			a.game.Match != nil && a.game.Match.State == game.MatchEnded
+
 		// update server in case keys are pressed
-		movement := a.game.Player.GetPlayerMovement()
+		movement := getPlayerMovement()
 
 		// update server in case lbm is pressed
-		shooting := a.game.Player.GetPlayerShooting(a.game.Camera)
+		shooting := getPlayerShooting(a.game.Camera)
+
+		// switch the weapon in case the keys are pressed or the wheel is scrolled
+		weapon := getWeaponSwitch(a.game.Player)
 
 		// create and init a pointer to the PlayerUpdateMessage instance
 		playerUpdate := &model.PlayerUpdateMessage{
@@ -61,6 +100,9 @@ func (a *App) Update() error {
 			RightKeyPressed: movement.RightKeyPressed,
 			Shot:            shooting.Shot,
 			Aim:             shooting.Aim,
+			Weapon:          weapon,
+			Reload:          getPlayerReload(),
+			Dash:            getPlayerDash(),
 		}
 
 		a.game.GameMutex.RUnlock()
@@ -85,8 +127,40 @@ func (a *App) runGame() {
 	// init the client game
 	a.game.InitClientGame()
 
+	// measure the ping using pong messages
+	a.conn.SetPongHandler(func(payload string) error {
+		rtt, err := netstats.ParsePongPayload(payload, time.Now())
+		if err != nil {
+			logger.Warn("failed to parse a pong message from the server: ", err)
+			return nil
+		}
+		a.netStats.Pong(rtt)
+		return nil
+	})
+
 	// start reading updates from the server
 	go a.readServerUpdates()
+
+	// start pinging the server
+	go a.pingServer()
+}
+
+// pingServer sends ping messages with the sending time
+// to the server in infinite loop. The server answers
+// with pong messages which are used to count the round trip time.
+// The loop ends when the connection is closed.
+func (a *App) pingServer() {
+	ticker := time.NewTicker(pingInterval)
+	defer ticker.Stop()
+
+	for range ticker.C {
+		now := time.Now()
+		err := a.conn.WriteControl(websocket.PingMessage, netstats.PingPayload(now), now.Add(pingInterval))
+		if err != nil {
+			// the connection is closed
+			return
+		}
+	}
 }
 
 // readServerUpdates reads game state updates from the server
@@ -114,13 +188,19 @@ func (a *App) readServerUpdates() {
 			logger.Warn("failed to decode a message from the server: ", err)
 		}
 
+		// register the snapshot in the network statistics
+		a.netStats.Received(len(msg), gameUpdate.Sequence)
+
 		// update client game
 		updateGame(a.game, &gameUpdate)
 	}
 }
 
 // updateGame updates a client's game state using
-// the received data about the game.
+// the received data about the game. The server sends only
+// the squares and the obstacles near the player, so they are merged
+// with the known ones and the squares that have left
+// the player's area are removed.
 //
 // Accepts a pointer to the update data.
 func updateGame(g *game.Game, gameUpdate *model.GameUpdateMessage) {
@@ -128,16 +208,50 @@ func updateGame(g *game.Game, gameUpdate *model.GameUpdateMessage) {
 	defer g.GameMutex.Unlock()
 
 	// update game squares on the client using data from the server
-	g.Squares = gameUpdate.Squares
+	for id, square := range gameUpdate.Squares {
+		g.Squares[id] = square
+	}
+
+	// remove squares that are not visible anymore
+	for _, id := range gameUpdate.Left {
+		delete(g.Squares, id)
+	}
 
 	// update user's square
 	g.Player = g.Squares[g.Player.Id]
 
 	// update game obstacles on the client side using data from the server
-	g.Arena.Obstacles = gameUpdate.Obstacles
+	for id, obstacle := range gameUpdate.Obstacles {
+		g.Arena.Obstacles[id] = obstacle
+	}
+
+	// update the scoreboard if it is sent
+	if gameUpdate.Scoreboard != nil {
+		g.Scoreboard = gameUpdate.Scoreboard
+	}
+
+	// show the kills in the kill feed
+	g.AddKills(gameUpdate.Kills, time.Now())
+
+	// update the state of the match
+	if gameUpdate.Match != nil {
+		g.Match = gameUpdate.Match
+	}
+
+	// update the objectives of the game mode
+	g.Flags = gameUpdate.Flags
+	g.Zones = gameUpdate.Zones
+	g.SafeZone = gameUpdate.SafeZone
+	g.Spectating = gameUpdate.Spectating
+
+	// update the pickups on the arena
+	g.Arena.Pickups = gameUpdate.Pickups
 
 	// move the game Camera to the player
-	if g.Player != nil {
+	// or to the square the eliminated player watches
+	if spectated := g.Squares[g.Spectating]; spectated != nil {
+		g.Camera.Move(spectated.Position)
+	} else if g.Player != nil {
 		g.Camera.Move(g.Player.Position)
 	}
 }
@@ -150,5 +264,9 @@ func (a *App) sendUpdateToServer(playerUpdate *model.PlayerUpdateMessage) {
 	msg, _ := json.Marshal(playerUpdate)
 	if err := a.conn.WriteMessage(websocket.TextMessage, msg); err != nil {
 		logger.Warn("failed to send update message to the server: ", err)
+		return
 	}
+
+	// register the message in the network statistics
+	a.netStats.Sent(len(msg))
 }
@@ -3,6 +3,7 @@ package config
 import (
 	"github.com/joho/godotenv"
 	"online_shooter/internal/logger"
+	"os"
 )
 
 const (
@@ -19,6 +20,16 @@ const (
 	bulletDamageEnvName = "BULLET_DAMAGE"
 	bulletSizeEnvName   = "BULLET_SIZE"
 	bulletSpeedEnvName  = "BULLET_SPEED"
+
+	tlsCertFileEnvName           = "TLS_CERT_FILE"
+	tlsKeyFileEnvName            = "TLS_KEY_FILE"
+	tlsCAFileEnvName             = "TLS_CA_FILE"
+	tlsInsecureSkipVerifyEnvName = "TLS_INSECURE_SKIP_VERIFY"
+
+	gameSeedEnvName      = "GAME_SEED"
+	recordingsDirEnvName = "RECORDINGS_DIR"
+
+	defaultRecordingsDir = "recordings"
 )
 
 type GameConfig struct {
@@ -35,10 +46,33 @@ type GameConfig struct {
 	BulletDamage *int32
 	BulletSize   *float32
 	BulletSpeed  *float32
+
+	TLSCertFile           *string
+	TLSKeyFile            *string
+	TLSCAFile             *string
+	TLSInsecureSkipVerify *bool
+
+	GameSeed      *int64
+	RecordingsDir *string
 }
 
 var config = GameConfig{}
 
+// gameEnvNames are names of the variables
+// which change the game simulation
+var gameEnvNames = []string{
+	screenWidthEnvName,
+	screenHeightEnvName,
+	squareHealthEnvName,
+	squareSizeEnvName,
+	squareSpeedEnvName,
+	obstacleHealthEnvName,
+	obstacleSizeEnvName,
+	bulletDamageEnvName,
+	bulletSizeEnvName,
+	bulletSpeedEnvName,
+}
+
 // Load loads variables from env file
 // to the process environmental variables.
 //
@@ -48,3 +82,27 @@ func Load(path string) {
 		logger.Fatal("failed to load variables from env file: ", err)
 	}
 }
+
+// GameValues returns the values of the variables which change
+// the game simulation, so a recorded match can be simulated
+// again with the same configuration.
+//
+// Returns the values by the names of the variables.
+func GameValues() map[string]string {
+	values := make(map[string]string, len(gameEnvNames))
+	for _, name := range gameEnvNames {
+		values[name] = os.Getenv(name)
+	}
+
+	return values
+}
+
+// SetGameValues sets the variables which change the game
+// simulation. It must be called before the config is read.
+//
+// Accepts the values by the names of the variables.
+func SetGameValues(values map[string]string) {
+	for name, value := range values {
+		os.Setenv(name, value)
+	}
+}
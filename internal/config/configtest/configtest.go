@@ -0,0 +1,35 @@
+// Package configtest provides the game configuration
+// shared by the tests of the game packages.
+package configtest
+
+import (
+	"os"
+	"testing"
+)
+
+// env contains the game variables the tests rely on,
+// the golden files of the harness are made with them
+var env = map[string]string{
+	"SCREEN_WIDTH":    "1280",
+	"SCREEN_HEIGHT":   "720",
+	"SQUARE_HEALTH":   "100",
+	"SQUARE_SIZE":     "40",
+	"SQUARE_SPEED":    "300",
+	"OBSTACLE_HEALTH": "100",
+	"OBSTACLE_SIZE":   "80",
+	"BULLET_DAMAGE":   "20",
+	"BULLET_SIZE":     "10",
+	"BULLET_SPEED":    "600",
+}
+
+// Main sets the game variables and runs the tests.
+// It is meant to be called from TestMain.
+//
+// Accepts a pointer to the tests.
+func Main(m *testing.M) {
+	for name, value := range env {
+		os.Setenv(name, value)
+	}
+
+	os.Exit(m.Run())
+}
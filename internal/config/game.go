@@ -0,0 +1,58 @@
+package config
+
+import (
+	"online_shooter/internal/logger"
+	"online_shooter/internal/utils"
+)
+
+// GameSeed returns a seed of the game's random source
+// from the config. If the seed is not initialized method gets it
+// from the environment.
+//
+// Returns the seed or 0 if it is not set
+// which means a new seed is generated for every game.
+// Zero can't be set explicitly as it is never a seed of a game.
+func GameSeed() int64 {
+	if config.GameSeed == nil {
+		var seed int64
+
+		// get the var from the environment
+		// the seed is optional so it is zero if it is not set
+		seedStr, err := utils.GetStringEnvVar(gameSeedEnvName)
+		if err == nil {
+			seed, err = utils.StringToInt64(seedStr)
+			if err != nil {
+				logger.Fatal(err)
+			}
+			if seed == 0 {
+				logger.Fatal(gameSeedEnvName, " must not be zero, leave it unset to generate a new seed")
+			}
+		}
+
+		// store the seed in the config
+		config.GameSeed = &seed
+	}
+
+	return *config.GameSeed
+}
+
+// RecordingsDir returns a path to the directory
+// with the match recordings from the config. If the path
+// is not initialized method gets it from the environment.
+//
+// Returns the path, "recordings" if it is not set.
+func RecordingsDir() string {
+	if config.RecordingsDir == nil {
+		// get the var from the environment
+		// the path is optional so the default one is used if it is not set
+		dir, err := utils.GetStringEnvVar(recordingsDirEnvName)
+		if err != nil {
+			dir = defaultRecordingsDir
+		}
+
+		// store the path in the config
+		config.RecordingsDir = &dir
+	}
+
+	return *config.RecordingsDir
+}
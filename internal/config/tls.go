@@ -0,0 +1,77 @@
+package config
+
+import (
+	"online_shooter/internal/utils"
+)
+
+// TLSCertFile returns a path to the server's certificate file
+// from the config. If the path is not initialized method gets it
+// from the environment.
+//
+// Returns the path or an empty string if it is not set.
+func TLSCertFile() string {
+	if config.TLSCertFile == nil {
+		// get the var from the environment
+		// the path is optional so it is empty if it is not set
+		certFile, _ := utils.GetStringEnvVar(tlsCertFileEnvName)
+
+		// store the path in the config
+		config.TLSCertFile = &certFile
+	}
+
+	return *config.TLSCertFile
+}
+
+// TLSKeyFile returns a path to the server's key file
+// from the config. If the path is not initialized method gets it
+// from the environment.
+//
+// Returns the path or an empty string if it is not set.
+func TLSKeyFile() string {
+	if config.TLSKeyFile == nil {
+		// get the var from the environment
+		// the path is optional so it is empty if it is not set
+		keyFile, _ := utils.GetStringEnvVar(tlsKeyFileEnvName)
+
+		// store the path in the config
+		config.TLSKeyFile = &keyFile
+	}
+
+	return *config.TLSKeyFile
+}
+
+// TLSCAFile returns a path to the file with certificates
+// the client trusts from the config. If the path is not
+// initialized method gets it from the environment.
+//
+// Returns the path or an empty string if it is not set.
+func TLSCAFile() string {
+	if config.TLSCAFile == nil {
+		// get the var from the environment
+		// the path is optional so it is empty if it is not set
+		caFile, _ := utils.GetStringEnvVar(tlsCAFileEnvName)
+
+		// store the path in the config
+		config.TLSCAFile = &caFile
+	}
+
+	return *config.TLSCAFile
+}
+
+// TLSInsecureSkipVerify returns a flag from the config which
+// shows if the client skips the server's certificate verification.
+// If the flag is not initialized method gets it from the environment.
+//
+// Returns the flag value, false if it is not set.
+func TLSInsecureSkipVerify() bool {
+	if config.TLSInsecureSkipVerify == nil {
+		// get the var from the environment
+		// the flag is optional so it is false if it is not set
+		insecure, _ := utils.GetBoolEnvVar(tlsInsecureSkipVerifyEnvName)
+
+		// store the flag in the config
+		config.TLSInsecureSkipVerify = &insecure
+	}
+
+	return *config.TLSInsecureSkipVerify
+}
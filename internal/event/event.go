@@ -5,4 +5,5 @@ type Event int
 const (
 	EventConnectToServer Event = iota
 	EventStartServer
+	EventWatchReplay
 )
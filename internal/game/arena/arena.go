@@ -1,9 +1,10 @@
 package arena
 
 import (
+	"math/rand"
 	"online_shooter/internal/config"
+	"online_shooter/internal/game/entity"
 	"online_shooter/internal/game/geometry"
-	"sync"
 )
 
 const (
@@ -13,27 +14,35 @@ const (
 )
 
 type Arena struct {
-	Width           float32
-	Height          float32
-	SquaresAmount   int
+	Width         float32
+	Height        float32
+	SquaresAmount int
+	// Teams is an amount of the teams, the spawn
+	// points of the teams are taken in turn
+	Teams           int
 	Spawns          []geometry.Point
 	ObstaclesAmount int
-	ArenaMutex      sync.RWMutex
 	Obstacles       map[int64]*Obstacle
+	Pickups         []*entity.Pickup
 }
 
 // NewArena creates and initializes
-// an arena instance with generated obstacles
-// and spawn points.
+// an arena instance with generated obstacles,
+// pickups and spawn points.
+//
+// Accepts the squares amount, the level of arena filling
+// with obstacles, an amount of the teams which is zero
+// if the squares play on their own and a pointer to the game's random source.
 //
 // Returns pointer to the created arena.
-func NewArena(squaresAmount int, obstaclesAmount string) *Arena {
+func NewArena(squaresAmount int, obstaclesAmount string, teams int, random *rand.Rand) *Arena {
 	// create an arena instance
 	// and set basic variables
 	arena := &Arena{
 		Width:           config.ScreenWidth(),
 		Height:          config.ScreenHeight(),
 		SquaresAmount:   squaresAmount,
+		Teams:           teams,
 		ObstaclesAmount: parseObstaclesAmount(obstaclesAmount),
 	}
 
@@ -41,10 +50,17 @@ func NewArena(squaresAmount int, obstaclesAmount string) *Arena {
 	arena.adaptToSquaresAmount(squaresAmount)
 
 	// generate obstacles
-	arena.generateObstacles()
+	arena.generateObstacles(random)
+
+	// place the pickups between the obstacles
+	arena.generatePickups()
 
 	// create spawns
-	arena.generateSpawns()
+	if teams > 1 {
+		arena.generateTeamSpawns()
+	} else {
+		arena.generateSpawns()
+	}
 
 	// return pointer to the arena
 	return arena
@@ -89,3 +105,21 @@ func parseObstaclesAmount(obstaclesAmount string) int {
 	}
 	return 8
 }
+
+// Clone creates a copy of the arena
+// which doesn't share obstacles and pickups with the original.
+//
+// Returns a pointer to the copy.
+func (a *Arena) Clone() *Arena {
+	c := *a
+	c.Spawns = append([]geometry.Point(nil), a.Spawns...)
+	c.Obstacles = make(map[int64]*Obstacle, len(a.Obstacles))
+	for id, o := range a.Obstacles {
+		c.Obstacles[id] = o.Clone()
+	}
+	c.Pickups = make([]*entity.Pickup, len(a.Pickups))
+	for i, p := range a.Pickups {
+		c.Pickups[i] = p.Clone()
+	}
+	return &c
+}
@@ -6,24 +6,28 @@ import (
 	"online_shooter/internal/config"
 	"online_shooter/internal/game/entity"
 	"online_shooter/internal/game/geometry"
-	"sync"
+	"online_shooter/internal/game/timer"
 	"time"
 )
 
 const secondsToRegenerate = 10
 
 type Obstacle struct {
-	sync.RWMutex
 	Id         int64
 	Position   geometry.Point
 	Health     int32
 	Size       float32
 	Vulnerable bool
+
+	// timer of the destroyed obstacle's regeneration
+	regeneration timer.Timer
 }
 
 // generateObstacles creates arena obstacles
 // generating random positions to each obstacle.
-func (a *Arena) generateObstacles() {
+//
+// Accepts a pointer to the game's random source.
+func (a *Arena) generateObstacles(random *rand.Rand) {
 	// init the map
 	a.Obstacles = make(map[int64]*Obstacle)
 
@@ -36,15 +40,15 @@ func (a *Arena) generateObstacles() {
 	// generating numberOfObstacles obstacles
 	for len(a.Obstacles) < a.ObstaclesAmount {
 		// random coords
-		x := rand.Float32()*(maxWidth-minWidth) + minWidth
-		y := rand.Float32()*(maxHeight-minHeight) + minHeight
+		x := random.Float32()*(maxWidth-minWidth) + minWidth
+		y := random.Float32()*(maxHeight-minHeight) + minHeight
 
 		// if position is valid
 		if a.isPositionValid(x, y) {
-			id := rand.Int63()
+			id := random.Int63()
 
 			for a.Obstacles[id] != nil {
-				id = rand.Int63()
+				id = random.Int63()
 			}
 
 			// create and init a new obstacle instance
@@ -93,8 +97,7 @@ func (a *Arena) isPositionValid(x, y float32) bool {
 // GetDamage reduces the health
 // and the size of the obstacle that was shot.
 //
-// Accepts a pointer to the bullet that damaged the obstacle
-// and a pointer to the slice of the obstacles.
+// Accepts a pointer to the bullet that damaged the obstacle.
 func (o *Obstacle) GetDamage(b *entity.Bullet) {
 	// reduce the obstacle's health
 	o.Health -= b.Damage
@@ -105,9 +108,7 @@ func (o *Obstacle) GetDamage(b *entity.Bullet) {
 		o.Vulnerable = false
 
 		// start obstacle's regeneration
-		go func() {
-			o.regenerate()
-		}()
+		o.regeneration.Start(secondsToRegenerate * time.Second)
 
 		return
 	}
@@ -116,22 +117,26 @@ func (o *Obstacle) GetDamage(b *entity.Bullet) {
 	o.Size -= o.Size * float32(b.Damage) / float32(o.Health) / 2
 }
 
-// regenerate starts a timer which end
-// restores the obstacle's health and size
-// and makes the obstacle visible.
-func (o *Obstacle) regenerate() {
-	// set a timer
-	timer := time.NewTimer(secondsToRegenerate * time.Second)
-	defer timer.Stop()
-
-	// wait till the timer ends
-	<-timer.C
-
-	o.Lock()
-	defer o.Unlock()
+// UpdateTimers advances the obstacle's regeneration timer.
+// When the timer finishes restores the destroyed obstacle's
+// health and size and makes the obstacle visible.
+//
+// Accepts the elapsed game time.
+func (o *Obstacle) UpdateTimers(dt time.Duration) {
+	if !o.regeneration.Advance(dt) {
+		return
+	}
 
 	// restore stats
 	o.Health = config.ObstacleHealth()
 	o.Size = config.ObstacleSize()
 	o.Vulnerable = true
 }
+
+// Clone creates a copy of the obstacle.
+//
+// Returns a pointer to the copy.
+func (o *Obstacle) Clone() *Obstacle {
+	c := *o
+	return &c
+}
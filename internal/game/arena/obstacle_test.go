@@ -0,0 +1,32 @@
+package arena
+
+import (
+	"online_shooter/internal/config"
+	"online_shooter/internal/config/configtest"
+	"online_shooter/internal/game/entity"
+	"testing"
+	"time"
+)
+
+func TestMain(m *testing.M) {
+	configtest.Main(m)
+}
+
+func TestObstacleRegeneration(t *testing.T) {
+	o := &Obstacle{Health: 10, Size: 20, Vulnerable: true}
+
+	o.GetDamage(&entity.Bullet{Damage: 20})
+	if o.Vulnerable {
+		t.Fatal("obstacle isn't destroyed")
+	}
+
+	o.UpdateTimers(secondsToRegenerate*time.Second - time.Millisecond)
+	if o.Vulnerable {
+		t.Fatal("obstacle is regenerated too early")
+	}
+
+	o.UpdateTimers(time.Millisecond)
+	if !o.Vulnerable || o.Health != config.ObstacleHealth() || o.Size != config.ObstacleSize() {
+		t.Fatalf("got vulnerable %v health %d size %v, want a restored obstacle", o.Vulnerable, o.Health, o.Size)
+	}
+}
@@ -0,0 +1,74 @@
+package arena
+
+import (
+	"online_shooter/internal/config"
+	"online_shooter/internal/game/entity"
+	"online_shooter/internal/game/geometry"
+)
+
+// pickupShiftAttempts is an amount of the shifts
+// of the pickup blocked by an obstacle towards the center
+const pickupShiftAttempts = 4
+
+// generatePickups places the pickups in the center of the arena,
+// in the centers of its quarters and between them. The speed boost
+// is in the center, the health packs and the ammo are in the opposite
+// quarters and the power-ups are between the quarters.
+// The pickups are placed in the same places for every game,
+// the pickup blocked by an obstacle is shifted towards the center.
+func (a *Arena) generatePickups() {
+	center := geometry.Point{X: a.Width / 2, Y: a.Height / 2}
+	places := []struct {
+		kind     entity.PickupKind
+		position geometry.Point
+	}{
+		{entity.SpeedPickup, center},
+		{entity.HealthPickup, geometry.Point{X: a.Width / 4, Y: a.Height / 4}},
+		{entity.HealthPickup, geometry.Point{X: a.Width * 3 / 4, Y: a.Height * 3 / 4}},
+		{entity.AmmoPickup, geometry.Point{X: a.Width * 3 / 4, Y: a.Height / 4}},
+		{entity.AmmoPickup, geometry.Point{X: a.Width / 4, Y: a.Height * 3 / 4}},
+		{entity.ShieldPickup, geometry.Point{X: a.Width / 2, Y: a.Height / 4}},
+		{entity.DamagePickup, geometry.Point{X: a.Width / 2, Y: a.Height * 3 / 4}},
+		{entity.RapidFirePickup, geometry.Point{X: a.Width / 4, Y: a.Height / 2}},
+		{entity.RapidFirePickup, geometry.Point{X: a.Width * 3 / 4, Y: a.Height / 2}},
+	}
+
+	a.Pickups = make([]*entity.Pickup, 0, len(places))
+	for _, place := range places {
+		position := place.position
+		for i := 0; i < pickupShiftAttempts && a.isBlocked(position); i++ {
+			position = shiftTowards(position, center, config.ObstacleSize())
+		}
+		a.Pickups = append(a.Pickups, entity.NewPickup(place.kind, position))
+	}
+}
+
+// isBlocked checks if the point is too close to an obstacle
+// for a square to take the pickup in it.
+//
+// Accepts the point to check.
+func (a *Arena) isBlocked(point geometry.Point) bool {
+	margin := config.SquareSize()
+	for _, o := range a.Obstacles {
+		if point.X >= o.Position.X-margin && point.X <= o.Position.X+o.Size+margin &&
+			point.Y >= o.Position.Y-margin && point.Y <= o.Position.Y+o.Size+margin {
+			return true
+		}
+	}
+	return false
+}
+
+// shiftTowards moves the point towards the target.
+//
+// Accepts the point, the target and the distance to move.
+//
+// Returns the moved point which doesn't pass the target.
+func shiftTowards(point, target geometry.Point, distance float32) geometry.Point {
+	vector := geometry.Vector{X: target.X - point.X, Y: target.Y - point.Y}
+	if vector.Length() <= distance {
+		return target
+	}
+
+	vector.Normalize()
+	return geometry.Point{X: point.X + vector.X*distance, Y: point.Y + vector.Y*distance}
+}
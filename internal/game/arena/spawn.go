@@ -69,3 +69,65 @@ func (a *Arena) generateSpawns() {
 		a.Spawns = append(a.Spawns, spawn)
 	}
 }
+
+// generateTeamSpawns generates spawn points for each player grouping them
+// by the teams: every team spawns on its own side of the arena.
+// The first team spawns on the left side, the second one on the right side,
+// the third one on the top side and the fourth one on the bottom side.
+// The spawn points of the teams are taken in turn, so the team
+// of the spawn point with index i is i % Teams + 1.
+func (a *Arena) generateTeamSpawns() {
+	// count spawn points per team
+	spawnsPerTeam := (a.SquaresAmount + a.Teams - 1) / a.Teams
+
+	for i := 0; i < a.SquaresAmount; i++ {
+		side := i % a.Teams
+		k := float32(i/a.Teams + 1)
+		step := 1 / float32(spawnsPerTeam+1)
+
+		var spawn geometry.Point
+		switch side {
+		case 0:
+			spawn = geometry.Point{X: 0, Y: k * step * a.Height}
+		case 1:
+			spawn = geometry.Point{X: a.Width, Y: k * step * a.Height}
+		case 2:
+			spawn = geometry.Point{X: k * step * a.Width, Y: 0}
+		default:
+			spawn = geometry.Point{X: k * step * a.Width, Y: a.Height}
+		}
+		a.Spawns = append(a.Spawns, spawn)
+	}
+}
+
+// SpawnTeam returns the team of the spawn point.
+//
+// Accepts an index of the spawn point.
+//
+// Returns the team or zero if the squares play on their own.
+func (a *Arena) SpawnTeam(index int) int {
+	if a.Teams <= 1 {
+		return 0
+	}
+	return index%a.Teams + 1
+}
+
+// TeamSpawns returns the spawn points of the team.
+//
+// Accepts the team which is zero if the squares play on their own.
+//
+// Returns the team's spawn points or all the spawn points
+// if the squares play on their own.
+func (a *Arena) TeamSpawns(team int) []geometry.Point {
+	if a.Teams <= 1 || team == 0 {
+		return a.Spawns
+	}
+
+	var spawns []geometry.Point
+	for i, spawn := range a.Spawns {
+		if a.SpawnTeam(i) == team {
+			spawns = append(spawns, spawn)
+		}
+	}
+	return spawns
+}
@@ -67,22 +67,61 @@ func (c *Camera) Move(point geometry.Point) {
 	c.checkBordersCollision()
 }
 
+// Translate moves the camera by the vector
+// keeping it inside the arena.
+//
+// Accepts the vector to move the camera by.
+func (c *Camera) Translate(v geometry.Vector) {
+	c.Position.X += v.X
+	c.Position.Y += v.Y
+
+	// check the borders
+	c.checkBordersCollision()
+}
+
 // checkBordersCollision checks the camera's collision
 // with the arena borders. If there is a collision
 // camera changes its position.
 func (c *Camera) checkBordersCollision() {
-	if c.Position.X < 0 {
-		c.Position.X = 0
+	c.Position = ClampPosition(c.Position, c.Width, c.Height, c.ArenaWidth, c.ArenaHeight)
+}
+
+// ClampPosition keeps a camera with the sizes inside the arena.
+//
+// Accepts the position of the camera, its sizes and the arena sizes.
+//
+// Returns the position of the camera inside the arena.
+func ClampPosition(position geometry.Point, width, height, arenaWidth, arenaHeight float32) geometry.Point {
+	if position.X < 0 {
+		position.X = 0
 	}
-	if c.Position.Y < 0 {
-		c.Position.Y = 0
+	if position.Y < 0 {
+		position.Y = 0
 	}
-	if c.Position.X > c.ArenaWidth-c.Width {
-		c.Position.X = c.ArenaWidth - c.Width
+	if position.X > arenaWidth-width {
+		position.X = arenaWidth - width
 	}
-	if c.Position.Y > c.ArenaHeight-c.Height {
-		c.Position.Y = c.ArenaHeight - c.Height
+	if position.Y > arenaHeight-height {
+		position.Y = arenaHeight - height
 	}
+
+	return position
+}
+
+// ViewAt counts the area visible by a camera
+// which follows the point and stays inside the arena.
+//
+// Accepts the followed point, the sizes of the camera and the arena sizes.
+//
+// Returns the visible area.
+func ViewAt(point geometry.Point, width, height, arenaWidth, arenaHeight float32) geometry.Rect {
+	// center the camera on the point the same way as Move does
+	position := ClampPosition(
+		geometry.Point{X: point.X - width/2, Y: point.Y - height/2},
+		width, height, arenaWidth, arenaHeight,
+	)
+
+	return geometry.Rect{X: position.X, Y: position.Y, Width: width, Height: height}
 }
 
 // WorldToScreen converts a point from the game world coordinate
@@ -0,0 +1,44 @@
+package clock
+
+import "time"
+
+// Clock provides the current time to the simulation.
+type Clock interface {
+	Now() time.Time
+}
+
+// Real is a clock returning the system time.
+type Real struct{}
+
+// Now returns the current system time.
+func (Real) Now() time.Time {
+	return time.Now()
+}
+
+// Manual is a clock which time changes only
+// when it is advanced. It is used to make
+// the simulation reproducible.
+type Manual struct {
+	now time.Time
+}
+
+// NewManual creates a manual clock.
+//
+// Accepts the initial time of the clock.
+//
+// Returns a pointer to the created clock.
+func NewManual(start time.Time) *Manual {
+	return &Manual{now: start}
+}
+
+// Now returns the current time of the clock.
+func (m *Manual) Now() time.Time {
+	return m.now
+}
+
+// Advance moves the clock forward.
+//
+// Accepts the duration to move the clock by.
+func (m *Manual) Advance(d time.Duration) {
+	m.now = m.now.Add(d)
+}
@@ -0,0 +1,38 @@
+package drawer
+
+import (
+	"fmt"
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/vector"
+	"image/color"
+	"online_shooter/internal/game/entity"
+	"online_shooter/internal/model"
+)
+
+// deathScreenShade is a shade over the arena while the player is dead
+var deathScreenShade = color.RGBA{A: 140}
+
+// drawDeathScreen shades the arena and shows the square's killer
+// in the killer's color and the countdown of the respawn.
+//
+// Accepts a pointer to the dead square, the scoreboard
+// to find the killer and a pointer to the screen.
+func drawDeathScreen(square *entity.Square, scoreboard []model.PlayerStatus, screen *ebiten.Image) {
+	bounds := screen.Bounds()
+	vector.DrawFilledRect(screen, 0, 0, float32(bounds.Dx()), float32(bounds.Dy()), deathScreenShade, false)
+
+	// the killer is unknown if it has left the game
+	killedBy, killerColor := "YOU DIED", color.Color(color.White)
+	for _, s := range scoreboard {
+		if square.Killer != 0 && s.Id == square.Killer {
+			killedBy = "KILLED BY PLAYER"
+			if s.IsBot {
+				killedBy = "KILLED BY BOT"
+			}
+			killerColor = s.Color
+		}
+	}
+
+	drawCenteredText(screen, killedBy, matchStatusY*4, killerColor)
+	drawCenteredText(screen, fmt.Sprintf("RESPAWN IN %.1fS", square.RespawnIn.Seconds()), matchStatusY*5, color.White)
+}
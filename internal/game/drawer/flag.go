@@ -0,0 +1,48 @@
+package drawer
+
+import (
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/vector"
+	"image/color"
+	"online_shooter/internal/game/camera"
+	"online_shooter/internal/game/entity"
+	"online_shooter/internal/game/game"
+)
+
+const (
+	baseAlpha          = 60
+	baseBorderWidth    = 2
+	flagPoleWidthRatio = 0.15
+)
+
+// DrawBase draws the base zone of the flag's team
+// as a translucent area with a border of the team's color.
+//
+// Accepts pointers to the flag, screen and camera objects as arguments.
+func DrawBase(flag *entity.Flag, screen *ebiten.Image, camera *camera.Camera) {
+	teamColor := game.TeamColor(flag.Team)
+	fill := color.RGBA{R: teamColor.R, G: teamColor.G, B: teamColor.B, A: baseAlpha}
+
+	inCamPosition := camera.WorldToScreen(flag.Base.Center())
+	x := inCamPosition.X - flag.Base.Width/2
+	y := inCamPosition.Y - flag.Base.Height/2
+	vector.DrawFilledRect(screen, x, y, flag.Base.Width, flag.Base.Height, fill, false)
+	vector.StrokeRect(screen, x, y, flag.Base.Width, flag.Base.Height, baseBorderWidth, teamColor, false)
+}
+
+// DrawFlag draws the flag as a pole with a cloth
+// of the team's color.
+//
+// Accepts pointers to the flag, screen and camera objects as arguments.
+func DrawFlag(flag *entity.Flag, screen *ebiten.Image, camera *camera.Camera) {
+	inCamPosition := camera.WorldToScreen(flag.Position)
+	poleWidth := flag.Size * flagPoleWidthRatio
+
+	// draw the pole
+	vector.DrawFilledRect(screen, inCamPosition.X, inCamPosition.Y, poleWidth, flag.Size,
+		color.White, false)
+
+	// draw the cloth
+	vector.DrawFilledRect(screen, inCamPosition.X+poleWidth, inCamPosition.Y, flag.Size-poleWidth, flag.Size/2,
+		game.TeamColor(flag.Team), false)
+}
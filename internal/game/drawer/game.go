@@ -0,0 +1,106 @@
+package drawer
+
+import (
+	"github.com/hajimehoshi/ebiten/v2"
+	"image/color"
+	"online_shooter/internal/game/game"
+)
+
+// DrawGame draws the game on the screen.
+//
+// Accepts pointers to the game and the screen as arguments.
+func DrawGame(g *game.Game, screen *ebiten.Image) {
+	g.GameMutex.RLock()
+	defer g.GameMutex.RUnlock()
+
+	// draw the flags' base zones and the control zones under the squares
+	for _, f := range g.Flags {
+		DrawBase(f, screen, g.Camera)
+	}
+	for _, z := range g.Zones {
+		DrawZone(z, screen, g.Camera)
+	}
+
+	// draw the pickups under the squares taking them
+	for _, p := range g.Arena.Pickups {
+		DrawPickup(p, screen, g.Camera)
+	}
+
+	// draw squares and bullets, the eliminated squares are out of the round
+	// and the dead ones wait for the respawn
+	for _, s := range g.Squares {
+		if s.Eliminated || s.Dead {
+			continue
+		}
+		DrawSquare(s, screen, g.Camera)
+		DrawBullets(s, screen, g.Camera, s.Color)
+	}
+
+	// draw the flags over their carriers
+	for _, f := range g.Flags {
+		DrawFlag(f, screen, g.Camera)
+	}
+
+	// draw obstacles
+	for _, o := range g.Arena.Obstacles {
+		DrawObstacle(o, screen, g.Camera)
+	}
+
+	// draw the safe zone over the arena
+	if g.SafeZone != nil {
+		DrawSafeZone(g.SafeZone, screen, g.Camera)
+		drawSafeZoneTimer(g.SafeZone, screen)
+	}
+
+	// show the latest kills to every player
+	var playerId int64
+	if g.Player != nil {
+		playerId = g.Player.Id
+	}
+	drawKillFeed(g.KillFeed, g.Scoreboard, playerId, screen)
+
+	// the eliminated player watches the rest of the round
+	if g.Player != nil && g.Player.Eliminated {
+		drawCenteredText(screen, "ELIMINATED - SPECTATING", matchStatusY*4, color.White)
+		return
+	}
+
+	// the dead player waits for the respawn
+	if g.Player != nil && g.Player.Dead {
+		drawDeathScreen(g.Player, g.Scoreboard, screen)
+		return
+	}
+
+	// draw player's stats
+	if g.Player != nil {
+		DrawSquareStats(g.Player, screen)
+
+		// remind the player to bring the flag home
+		for _, f := range g.Flags {
+			if f.Carrier == g.Player.Id {
+				drawCenteredText(screen, "YOU HAVE THE FLAG", matchStatusY*3, game.TeamColor(f.Team))
+			}
+		}
+	}
+
+	// show who holds the control zones
+	if len(g.Zones) > 0 {
+		drawZoneHolders(g.Zones, screen)
+	}
+}
+
+// DrawGameScoreboard draws the scoreboard of the game on the screen.
+//
+// Accepts pointers to the game and the screen as arguments.
+func DrawGameScoreboard(g *game.Game, screen *ebiten.Image) {
+	g.GameMutex.RLock()
+	defer g.GameMutex.RUnlock()
+
+	// get the player's id
+	var playerId int64
+	if g.Player != nil {
+		playerId = g.Player.Id
+	}
+
+	DrawScoreboard(g.Scoreboard, playerId, screen)
+}
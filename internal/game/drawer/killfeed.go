@@ -0,0 +1,118 @@
+package drawer
+
+import (
+	"fmt"
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/text"
+	"golang.org/x/image/font"
+	"image/color"
+	"online_shooter/internal/assets"
+	"online_shooter/internal/game/entity"
+	"online_shooter/internal/game/game"
+	"online_shooter/internal/model"
+	"time"
+)
+
+const (
+	killFeedY           = 30
+	killFeedLineSpacing = 30
+	killFeedMargin      = 20
+	// killFeedStreak is the shortest kill streak shown in the kill feed
+	killFeedStreak = 3
+)
+
+// killFeedPart is a part of the kill feed's line drawn in its color.
+type killFeedPart struct {
+	text  string
+	color color.Color
+}
+
+// drawKillFeed draws the latest kills in the top right corner
+// of the screen: the killer and the amount of the assists,
+// the weapon and the victim in the squares' colors.
+// The long kill streaks are shown after the kill.
+//
+// Accepts the kill feed, the scoreboard to find the squares,
+// an id of the player's square and a pointer to the screen.
+func drawKillFeed(feed []game.KillFeedEntry, scoreboard []model.PlayerStatus, playerId int64, screen *ebiten.Image) {
+	now := time.Now()
+	y := killFeedY
+	for _, entry := range feed {
+		if entry.Expired(now) {
+			continue
+		}
+
+		parts := killFeedLine(entry.KillEvent, scoreboard, playerId)
+
+		// align the line to the right side of the screen
+		width := 0
+		for _, part := range parts {
+			width += font.MeasureString(assets.Font(), part.text).Ceil()
+		}
+		x := screen.Bounds().Dx() - killFeedMargin - width
+		for _, part := range parts {
+			text.Draw(screen, part.text, assets.Font(), x, y, part.color)
+			x += font.MeasureString(assets.Font(), part.text).Ceil()
+		}
+
+		y += killFeedLineSpacing
+	}
+}
+
+// killFeedLine splits the kill into the parts of the kill feed's line.
+//
+// Accepts the kill, the scoreboard to find the squares
+// and an id of the player's square.
+//
+// Returns the parts of the line.
+func killFeedLine(kill model.KillEvent, scoreboard []model.PlayerStatus, playerId int64) []killFeedPart {
+	victimName, victimColor := squareLabel(kill.Victim, scoreboard, playerId)
+	victim := killFeedPart{victimName, victimColor}
+
+	// the victim has died not by a square
+	if kill.Killer == 0 {
+		return []killFeedPart{victim, {" DIED", color.White}}
+	}
+
+	killerName, killerColor := squareLabel(kill.Killer, scoreboard, playerId)
+	parts := []killFeedPart{{killerName, killerColor}}
+	if len(kill.Assists) > 0 {
+		parts = append(parts, killFeedPart{fmt.Sprintf(" +%d", len(kill.Assists)), color.White})
+	}
+	parts = append(parts,
+		killFeedPart{fmt.Sprintf(" [%s] ", entity.NewWeapon(kill.Weapon).Name), color.White},
+		victim,
+	)
+	if kill.Streak >= killFeedStreak {
+		parts = append(parts, killFeedPart{fmt.Sprintf("  STREAK %d", kill.Streak), playerRowColor})
+	}
+
+	return parts
+}
+
+// squareLabel finds the name and the color
+// of the square as they are shown in the scoreboard.
+//
+// Accepts an id of the square, the scoreboard
+// and an id of the player's square.
+//
+// Returns the name and the color of the square, the unknown
+// square which isn't in the scoreboard yet is drawn in white.
+func squareLabel(id int64, scoreboard []model.PlayerStatus, playerId int64) (string, color.Color) {
+	for _, s := range scoreboard {
+		if s.Id != id {
+			continue
+		}
+
+		switch {
+		case s.Id == playerId:
+			return "YOU", s.Color
+		case s.IsBot:
+			return "BOT", s.Color
+		default:
+			return "PLAYER", s.Color
+		}
+	}
+
+	return "SQUARE", color.White
+}
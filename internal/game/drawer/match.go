@@ -0,0 +1,147 @@
+package drawer
+
+import (
+	"fmt"
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/text"
+	"golang.org/x/image/font"
+	"image/color"
+	"online_shooter/internal/assets"
+	"online_shooter/internal/game/game"
+	"online_shooter/internal/model"
+)
+
+const matchStatusY = 30
+
+// DrawGameMatch draws the state of the match on the screen.
+//
+// Accepts pointers to the game and the screen as arguments.
+func DrawGameMatch(g *game.Game, screen *ebiten.Image) {
+	g.GameMutex.RLock()
+	defer g.GameMutex.RUnlock()
+
+	if g.Match == nil {
+		return
+	}
+
+	// get the player's id
+	var playerId int64
+	if g.Player != nil {
+		playerId = g.Player.Id
+	}
+
+	DrawMatchStatus(g.Match, g.Scoreboard, playerId, screen)
+}
+
+// DrawMatchStatus draws the state of the match at the top
+// of the screen: the warmup, the countdown before the round,
+// the time left in the round or the winner of the round
+// with the time till the next one.
+//
+// Accepts a pointer to the state of the match, the squares' stats
+// to find the winner, an id of the player's square and a pointer to the screen.
+func DrawMatchStatus(status *model.MatchStatus, scoreboard []model.PlayerStatus, playerId int64, screen *ebiten.Image) {
+	var line string
+	switch status.State {
+	case game.MatchWarmup:
+		line = "WARMUP " + formatDuration(status.Remaining)
+	case game.MatchCountdown:
+		line = "ROUND STARTS IN " + formatDuration(status.Remaining)
+	case game.MatchLive:
+		// the round without the time limit has no timer
+		if status.Remaining <= 0 {
+			return
+		}
+		line = formatDuration(status.Remaining)
+	case game.MatchEnded:
+		line = fmt.Sprintf("ROUND OVER  WINNER: %s  NEXT ROUND IN %s",
+			winnerName(status, scoreboard, playerId), formatDuration(status.Remaining))
+	}
+
+	if line != "" {
+		drawCenteredText(screen, line, matchStatusY, color.White)
+	}
+
+	// draw the scores of the teams
+	if len(status.TeamScores) > 0 {
+		drawTeamScores(status.TeamScores, screen)
+	}
+
+	// draw the waves of the survival
+	if status.Mode == game.ModeSurvival && status.State != game.MatchEnded {
+		drawWaveStatus(status, screen)
+	}
+}
+
+// drawWaveStatus draws the current wave and the shared lives
+// of the survival under the state of the match
+// with the time till the next wave during the break.
+//
+// Accepts a pointer to the state of the match and a pointer to the screen.
+func drawWaveStatus(status *model.MatchStatus, screen *ebiten.Image) {
+	line := fmt.Sprintf("WAVE %d  LIVES %d", status.Wave, status.Lives)
+	if status.WaveBreak > 0 {
+		line += "  NEXT WAVE IN " + formatDuration(status.WaveBreak)
+	}
+	drawCenteredText(screen, line, matchStatusY*2, color.White)
+}
+
+// drawTeamScores draws the scores of the teams in their colors
+// in a row under the state of the match.
+//
+// Accepts the scores of the teams by their order and a pointer to the screen.
+func drawTeamScores(scores []int, screen *ebiten.Image) {
+	const gap = 30
+
+	// count the width of the row to center it
+	labels := make([]string, len(scores))
+	width := 0
+	for i, score := range scores {
+		labels[i] = fmt.Sprintf("%s %d", game.TeamName(i+1), score)
+		width += font.MeasureString(assets.Font(), labels[i]).Ceil()
+	}
+	width += gap * (len(scores) - 1)
+
+	x := (screen.Bounds().Dx() - width) / 2
+	for i, label := range labels {
+		text.Draw(screen, label, assets.Font(), x, matchStatusY*2, game.TeamColor(i+1))
+		x += font.MeasureString(assets.Font(), label).Ceil() + gap
+	}
+}
+
+// winnerName returns the name of the round's winner
+// as it is shown in the scoreboard.
+//
+// Accepts a pointer to the state of the match, the squares' stats
+// and an id of the player's square.
+func winnerName(status *model.MatchStatus, scoreboard []model.PlayerStatus, playerId int64) string {
+	if status.Mode == game.ModeSurvival {
+		if status.WinnerTeam == game.SurvivorsTeam {
+			return "SURVIVORS"
+		}
+		return fmt.Sprintf("BOTS AT WAVE %d", status.Wave)
+	}
+	if status.Mode == game.ModeInfection {
+		if status.WinnerTeam == game.SurvivorsTeam {
+			return "SURVIVORS"
+		}
+		return "INFECTED"
+	}
+	if status.WinnerTeam != 0 {
+		return game.TeamName(status.WinnerTeam) + " TEAM"
+	}
+
+	winner := status.Winner
+	if winner == 0 {
+		return "NOBODY"
+	}
+	if winner == playerId {
+		return "YOU"
+	}
+	for _, s := range scoreboard {
+		if s.Id == winner && s.IsBot {
+			return "BOT"
+		}
+	}
+	return "PLAYER"
+}
@@ -0,0 +1,72 @@
+package drawer
+
+import (
+	"fmt"
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/text"
+	"github.com/hajimehoshi/ebiten/v2/vector"
+	"image/color"
+	"online_shooter/internal/assets"
+	"online_shooter/internal/netstats"
+)
+
+const (
+	netGraphX        = 260
+	netGraphBarWidth = 3
+	netGraphHeight   = 60
+	netGraphMaxMs    = 100
+	netGraphLateMs   = 50
+	bytesInKilobyte  = 1024
+)
+
+// DrawNetGraph draws the network statistics next to
+// the square's stats. Statistic includes the ping, the jitter,
+// the snapshot rate, the traffic and the amount of dropped
+// and late snapshots. Under the text the graph of the
+// intervals between snapshots is drawn.
+//
+// Accepts the network statistics summary and a pointer to the screen.
+func DrawNetGraph(summary netstats.Summary, screen *ebiten.Image) {
+	lines := []string{
+		fmt.Sprintf("PING: %d MS", summary.Ping.Milliseconds()),
+		fmt.Sprintf("JITTER: %.1f MS", float64(summary.Jitter.Microseconds())/1000),
+		fmt.Sprintf("SNAPSHOTS: %d/S", summary.SnapshotRate),
+		fmt.Sprintf("UP: %.1f KB/S", float64(summary.BytesUpPerSecond)/bytesInKilobyte),
+		fmt.Sprintf("DOWN: %.1f KB/S", float64(summary.BytesDownPerSecond)/bytesInKilobyte),
+		fmt.Sprintf("DROPPED: %d LATE: %d", summary.Dropped, summary.Late),
+	}
+
+	textColor := color.White
+
+	// draw the statistics
+	y := 30
+	for _, line := range lines {
+		text.Draw(screen, line, assets.Font(), netGraphX, y, textColor)
+		y += 30
+	}
+
+	// draw the graph of the snapshot intervals
+	bottom := float32(y + netGraphHeight - 20)
+	for i, interval := range summary.Intervals {
+		if interval > netGraphMaxMs {
+			interval = netGraphMaxMs
+		}
+
+		// highlight the late snapshots
+		barColor := color.RGBA{R: 0, G: 200, B: 0, A: 255}
+		if interval > netGraphLateMs {
+			barColor = color.RGBA{R: 200, G: 0, B: 0, A: 255}
+		}
+
+		height := interval / netGraphMaxMs * netGraphHeight
+		vector.DrawFilledRect(
+			screen,
+			float32(netGraphX+i*netGraphBarWidth),
+			bottom-height,
+			netGraphBarWidth-1,
+			height,
+			barColor,
+			false,
+		)
+	}
+}
@@ -0,0 +1,64 @@
+package drawer
+
+import (
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/vector"
+	"image/color"
+	"online_shooter/internal/game/camera"
+	"online_shooter/internal/game/entity"
+)
+
+const (
+	// pickupCrossRatio is a width of the health cross's bars
+	// and the ammo box's stripe as a part of the pickup size
+	pickupCrossRatio  = 0.3
+	pickupBorderWidth = 2
+)
+
+var (
+	healthPickupColor = color.RGBA{R: 230, G: 40, B: 40, A: 255}
+	ammoPickupColor   = color.RGBA{R: 200, G: 170, B: 60, A: 255}
+	speedPickupColor  = color.RGBA{R: 60, G: 220, B: 240, A: 255}
+	shieldPickupColor = color.RGBA{R: 80, G: 120, B: 255, A: 255}
+	damagePickupColor = color.RGBA{R: 255, G: 120, B: 0, A: 255}
+	firePickupColor   = color.RGBA{R: 230, G: 60, B: 230, A: 255}
+)
+
+// DrawPickup draws the active pickup: the health pack
+// as a red cross, the ammo as a box with a stripe,
+// the speed boost as a cyan circle, the shield as a blue ring,
+// the double damage as an orange cross and the rapid fire as three bars.
+//
+// Accepts pointers to the pickup, screen and camera objects as arguments.
+func DrawPickup(pickup *entity.Pickup, screen *ebiten.Image, camera *camera.Camera) {
+	if !pickup.Active {
+		return
+	}
+
+	inCamPosition := camera.WorldToScreen(pickup.Position)
+	x, y, size := inCamPosition.X, inCamPosition.Y, pickup.Size
+	bar := size * pickupCrossRatio
+
+	switch pickup.Kind {
+	case entity.HealthPickup:
+		vector.DrawFilledRect(screen, x, y, size, size, color.White, false)
+		vector.DrawFilledRect(screen, x+(size-bar)/2, y, bar, size, healthPickupColor, false)
+		vector.DrawFilledRect(screen, x, y+(size-bar)/2, size, bar, healthPickupColor, false)
+	case entity.AmmoPickup:
+		vector.DrawFilledRect(screen, x, y, size, size, ammoPickupColor, false)
+		vector.DrawFilledRect(screen, x, y+(size-bar)/2, size, bar, color.Black, false)
+		vector.StrokeRect(screen, x, y, size, size, pickupBorderWidth, color.Black, false)
+	case entity.SpeedPickup:
+		vector.DrawFilledCircle(screen, x+size/2, y+size/2, size/2, speedPickupColor, true)
+		vector.StrokeCircle(screen, x+size/2, y+size/2, size/2, pickupBorderWidth, color.White, true)
+	case entity.ShieldPickup:
+		vector.StrokeCircle(screen, x+size/2, y+size/2, size/2-bar/2, bar, shieldPickupColor, true)
+	case entity.DamagePickup:
+		vector.StrokeLine(screen, x, y, x+size, y+size, bar, damagePickupColor, true)
+		vector.StrokeLine(screen, x+size, y, x, y+size, bar, damagePickupColor, true)
+	case entity.RapidFirePickup:
+		for i := float32(0); i < 3; i++ {
+			vector.DrawFilledRect(screen, x+i*size*2/5, y, size/5, size, firePickupColor, false)
+		}
+	}
+}
@@ -0,0 +1,54 @@
+package drawer
+
+import (
+	"fmt"
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/text"
+	"github.com/hajimehoshi/ebiten/v2/vector"
+	"image/color"
+	"online_shooter/internal/assets"
+	"online_shooter/internal/replay"
+)
+
+const (
+	replayBarHeight = 8
+	replayBarMargin = 20
+)
+
+// DrawReplayHUD draws the replay controls at the bottom
+// of the screen: the playback time and speed, the camera mode,
+// the progress bar and the hint with the keys.
+//
+// Accepts a pointer to the replay player, a flag showing
+// if the camera follows a square and a pointer to the screen.
+func DrawReplayHUD(p *replay.Player, follow bool, screen *ebiten.Image) {
+	width := float32(screen.Bounds().Dx())
+	height := float32(screen.Bounds().Dy())
+
+	// draw the playback state
+	state := fmt.Sprintf("REPLAY %.1fS / %.1fS  X%.2f", p.Position.Seconds(), p.Replay.Duration().Seconds(), p.Speed)
+	if p.Paused {
+		state += "  PAUSED"
+	}
+	if follow {
+		state += "  CAMERA: FOLLOW"
+	} else {
+		state += "  CAMERA: FREE"
+	}
+	text.Draw(screen, state, assets.Font(), replayBarMargin, int(height-70), color.White)
+
+	// draw the progress bar
+	barWidth := width - 2*replayBarMargin
+	progress := float32(1)
+	if duration := p.Replay.Duration(); duration > 0 {
+		progress = float32(p.Position) / float32(duration)
+	}
+	vector.DrawFilledRect(screen, replayBarMargin, height-50, barWidth, replayBarHeight,
+		color.RGBA{R: 100, G: 100, B: 100, A: 255}, false)
+	vector.DrawFilledRect(screen, replayBarMargin, height-50, barWidth*progress, replayBarHeight,
+		color.RGBA{R: 0, G: 200, B: 0, A: 255}, false)
+
+	// draw the hint
+	hint := "SPACE PAUSE  LEFT/RIGHT SEEK  UP/DOWN SPEED  C CAMERA  Q/E SQUARE  WASD MOVE  ESC EXIT"
+	text.Draw(screen, hint, assets.Font(), replayBarMargin, int(height-15), color.White)
+}
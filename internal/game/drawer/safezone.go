@@ -0,0 +1,50 @@
+package drawer
+
+import (
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/vector"
+	"image/color"
+	"online_shooter/internal/game/camera"
+	"online_shooter/internal/game/entity"
+)
+
+const (
+	safeZoneWidth     = 3
+	nextSafeZoneWidth = 1
+)
+
+var (
+	safeZoneColor     = color.RGBA{R: 80, G: 160, B: 255, A: 255}
+	nextSafeZoneColor = color.RGBA{R: 255, G: 255, B: 255, A: 160}
+)
+
+// DrawSafeZone draws the current circle of the safe zone
+// and the next circle it shrinks to.
+//
+// Accepts pointers to the safe zone, screen and camera objects as arguments.
+func DrawSafeZone(zone *entity.SafeZone, screen *ebiten.Image, camera *camera.Camera) {
+	// the next circle isn't drawn after the last phase
+	if zone.NextRadius < zone.Radius {
+		next := camera.WorldToScreen(zone.NextCenter)
+		vector.StrokeCircle(screen, next.X, next.Y, zone.NextRadius, nextSafeZoneWidth, nextSafeZoneColor, true)
+	}
+
+	center := camera.WorldToScreen(zone.Center)
+	vector.StrokeCircle(screen, center.X, center.Y, zone.Radius, safeZoneWidth, safeZoneColor, true)
+}
+
+// drawSafeZoneTimer draws the time till the safe zone
+// starts or ends shrinking under the state of the match.
+//
+// Accepts a pointer to the safe zone and a pointer to the screen.
+func drawSafeZoneTimer(zone *entity.SafeZone, screen *ebiten.Image) {
+	if zone.Remaining <= 0 {
+		return
+	}
+
+	line := "ZONE SHRINKS IN " + formatDuration(zone.Remaining)
+	if zone.Shrinking {
+		line = "ZONE SHRINKING " + formatDuration(zone.Remaining)
+	}
+	drawCenteredText(screen, line, matchStatusY*3, safeZoneColor)
+}
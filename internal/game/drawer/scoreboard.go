@@ -0,0 +1,101 @@
+package drawer
+
+import (
+	"fmt"
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/text"
+	"github.com/hajimehoshi/ebiten/v2/vector"
+	"image/color"
+	"online_shooter/internal/assets"
+	"online_shooter/internal/model"
+)
+
+const (
+	scoreboardRowHeight = 30
+	scoreboardPadding   = 20
+)
+
+// playerRowColor is a color of the player's row in the scoreboard
+var playerRowColor = color.RGBA{R: 255, G: 215, A: 255}
+
+// scoreboardColumns are the titles of the scoreboard's columns
+// and the positions of the columns as parts of the table's width
+var scoreboardColumns = []struct {
+	title    string
+	position float32
+}{
+	{"SQUARE", 0},
+	{"K", 0.22},
+	{"D", 0.3},
+	{"A", 0.38},
+	{"DEALT", 0.46},
+	{"TAKEN", 0.57},
+	{"BEST", 0.68},
+	{"ACC", 0.78},
+	{"PING", 0.89},
+}
+
+// DrawScoreboard draws the table with every square's kills,
+// deaths, assists, damage dealt and taken, the best kill streak,
+// accuracy and ping in the center of the screen.
+// The player's row is highlighted.
+//
+// Accepts the squares' stats in the order to draw,
+// an id of the player's square and a pointer to the screen.
+func DrawScoreboard(scoreboard []model.PlayerStatus, playerId int64, screen *ebiten.Image) {
+	// count the table sizes
+	screenWidth := float32(screen.Bounds().Dx())
+	width := screenWidth * 0.8
+	height := float32((len(scoreboard)+1)*scoreboardRowHeight + 2*scoreboardPadding)
+	x := (screenWidth - width) / 2
+	y := float32(scoreboardPadding * 5)
+
+	// draw the background
+	vector.DrawFilledRect(screen, x, y, width, height, color.RGBA{A: 180}, false)
+
+	// count the columns
+	columns := make([]int, len(scoreboardColumns))
+	for i, column := range scoreboardColumns {
+		columns[i] = int(x+width*column.position) + scoreboardPadding
+	}
+
+	// draw the header
+	rowY := int(y) + scoreboardPadding + scoreboardRowHeight/2
+	for i, column := range scoreboardColumns {
+		text.Draw(screen, column.title, assets.Font(), columns[i], rowY, color.White)
+	}
+
+	// draw the rows
+	for _, s := range scoreboard {
+		rowY += scoreboardRowHeight
+
+		// draw the square's color
+		vector.DrawFilledRect(screen, float32(columns[0]), float32(rowY-16), 16, 16, s.Color, false)
+
+		name := "PLAYER"
+		ping := fmt.Sprintf("%d", s.Ping)
+		if s.IsBot {
+			name = "BOT"
+			ping = "-"
+		}
+		textColor := color.Color(color.White)
+		if s.Id == playerId {
+			name = "YOU"
+			textColor = playerRowColor
+		}
+
+		text.Draw(screen, name, assets.Font(), columns[0]+24, rowY, textColor)
+		for i, value := range []string{
+			fmt.Sprintf("%d", s.Kills),
+			fmt.Sprintf("%d", s.Deaths),
+			fmt.Sprintf("%d", s.Assists),
+			fmt.Sprintf("%d", s.DamageDealt),
+			fmt.Sprintf("%d", s.DamageTaken),
+			fmt.Sprintf("%d", s.BestStreak),
+			fmt.Sprintf("%.0f%%", s.Accuracy*100),
+			ping,
+		} {
+			text.Draw(screen, value, assets.Font(), columns[i+1], rowY, textColor)
+		}
+	}
+}
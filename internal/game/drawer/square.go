@@ -6,12 +6,32 @@ import (
 	"github.com/hajimehoshi/ebiten/v2/text"
 	"github.com/hajimehoshi/ebiten/v2/vector"
 	"image/color"
+	"math"
 	"online_shooter/internal/assets"
 	"online_shooter/internal/game/camera"
 	"online_shooter/internal/game/entity"
 )
 
-// DrawSquare draws the square on the screen.
+// size and position of the reloading progress bar
+const (
+	reloadBarY      = 165
+	reloadBarWidth  = 150
+	reloadBarHeight = 10
+	// dashBarX is a position of the dash's cooldown bar next to the reloading one
+	dashBarX     = 180
+	dashBarWidth = 80
+	// effectsY is a position of the first line of the effects' list
+	effectsY          = 225
+	effectLineSpacing = 30
+	// shieldRingWidth is a width of the ring around the protected square
+	shieldRingWidth = 3
+)
+
+// shieldRingColor is a color of the ring around the protected square
+var shieldRingColor = color.RGBA{R: 80, G: 120, B: 255, A: 255}
+
+// DrawSquare draws the square on the screen. The square
+// protected after the respawn or by the shield is drawn in a ring.
 //
 // Accepts pointers to the square to draw,
 // screen and camera objects as arguments.
@@ -27,22 +47,81 @@ func DrawSquare(square *entity.Square, screen *ebiten.Image, camera *camera.Came
 		square.Color,
 		true,
 	)
+
+	if !square.Vulnerable || square.HasEffect(entity.ShieldEffect) {
+		center := square.Size / 2
+		vector.StrokeCircle(screen, inCamPosition.X+center, inCamPosition.Y+center,
+			center*math.Sqrt2+shieldRingWidth, shieldRingWidth, shieldRingColor, true)
+	}
 }
 
 // DrawSquareStats draws the statistic about the square on the screen.
-// Statistic includes a square's health, an amount of kills and of deaths.
+// Statistic includes a square's health and armor, ammo, an amount of kills and of deaths,
+// the equipped weapon, the reloading progress, the dash's cooldown and the active effects.
 //
 // Accepts pointers to the square to draw stats and screen objects as arguments.
 func DrawSquareStats(square *entity.Square, screen *ebiten.Image) {
 	health := fmt.Sprintf("HEALTH: %d", square.Health)
-	bullets := fmt.Sprintf("BULLETS: %d", square.CountBulletsAmount())
+	if square.Armor > 0 {
+		health = fmt.Sprintf("HEALTH: %d  ARMOR: %d", square.Health, square.Armor)
+	}
+	ammo := fmt.Sprintf("AMMO: %d / %d", square.Ammo.Magazine, square.Ammo.Reserve)
+	if entity.NewWeapon(square.WeaponKind).Magazine == 0 {
+		ammo = "AMMO: UNLIMITED"
+	}
 	kills := fmt.Sprintf("KILLS: %d", square.Kills)
 	deaths := fmt.Sprintf("DEATHS: %d", square.Deaths)
+	weapon := fmt.Sprintf("WEAPON: %s", entity.NewWeapon(square.WeaponKind).Name)
 
 	textColor := color.White
 
 	text.Draw(screen, health, assets.Font(), 10, 30, textColor)
-	text.Draw(screen, bullets, assets.Font(), 10, 60, textColor)
+	text.Draw(screen, ammo, assets.Font(), 10, 60, textColor)
 	text.Draw(screen, kills, assets.Font(), 10, 90, textColor)
 	text.Draw(screen, deaths, assets.Font(), 10, 120, textColor)
+	text.Draw(screen, weapon, assets.Font(), 10, 150, textColor)
+
+	if square.ReloadProgress > 0 {
+		drawReloadBar(square.ReloadProgress, screen)
+	}
+	drawDashBar(square.DashCooldown, screen)
+	drawEffects(square.Effects, screen)
+}
+
+// drawEffects draws the list of the square's effects
+// with their stacks and remaining time under the square's stats.
+//
+// Accepts the effects and a pointer to the screen.
+func drawEffects(effects []entity.Effect, screen *ebiten.Image) {
+	for i, effect := range effects {
+		line := fmt.Sprintf("%s %.1fS", effect.Name(), effect.Remaining.Seconds())
+		if effect.Stacks > 1 {
+			line = fmt.Sprintf("%s X%d %.1fS", effect.Name(), effect.Stacks, effect.Remaining.Seconds())
+		}
+		text.Draw(screen, line, assets.Font(), 10, effectsY+i*effectLineSpacing, color.White)
+	}
+}
+
+// drawReloadBar draws the progress of the weapon's reloading
+// under the square's stats.
+//
+// Accepts the part of the reloading done and a pointer to the screen.
+func drawReloadBar(progress float32, screen *ebiten.Image) {
+	vector.StrokeRect(screen, 10, reloadBarY, reloadBarWidth, reloadBarHeight, 1, color.White, true)
+	vector.DrawFilledRect(screen, 10, reloadBarY, reloadBarWidth*progress, reloadBarHeight, color.White, true)
+	text.Draw(screen, "RELOADING", assets.Font(), 10, reloadBarY+reloadBarHeight+30, color.White)
+}
+
+// drawDashBar draws the bar filling up while the dash cools down
+// next to the reloading progress.
+//
+// Accepts the part of the dash's cooldown left and a pointer to the screen.
+func drawDashBar(cooldown float32, screen *ebiten.Image) {
+	label := "DASH"
+	if cooldown == 0 {
+		label = "DASH READY"
+	}
+	vector.StrokeRect(screen, dashBarX, reloadBarY, dashBarWidth, reloadBarHeight, 1, color.White, true)
+	vector.DrawFilledRect(screen, dashBarX, reloadBarY, dashBarWidth*(1-cooldown), reloadBarHeight, color.White, true)
+	text.Draw(screen, label, assets.Font(), dashBarX, reloadBarY+reloadBarHeight+30, color.White)
 }
@@ -1 +1,32 @@
 package drawer
+
+import (
+	"fmt"
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/text"
+	"golang.org/x/image/font"
+	"image/color"
+	"online_shooter/internal/assets"
+	"time"
+)
+
+// drawCenteredText draws a text in the horizontal center of the screen.
+//
+// Accepts a pointer to the screen, a string that needs to be printed,
+// a y coord of the text baseline and the color of the text.
+func drawCenteredText(screen *ebiten.Image, s string, y int, clr color.Color) {
+	textWidth := font.MeasureString(assets.Font(), s).Ceil()
+	x := (screen.Bounds().Dx() - textWidth) / 2
+	text.Draw(screen, s, assets.Font(), x, y, clr)
+}
+
+// formatDuration formats the time left as minutes and seconds
+// rounding it up, so the countdown shows zero only when it is over.
+//
+// Accepts the duration to format.
+//
+// Returns the formatted duration.
+func formatDuration(d time.Duration) string {
+	seconds := int((d + time.Second - 1) / time.Second)
+	return fmt.Sprintf("%d:%02d", seconds/60, seconds%60)
+}
@@ -0,0 +1,80 @@
+package drawer
+
+import (
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/vector"
+	"image/color"
+	"online_shooter/internal/game/camera"
+	"online_shooter/internal/game/entity"
+	"online_shooter/internal/game/game"
+	"strings"
+)
+
+const (
+	zoneAlpha          = 50
+	zoneBorderWidth    = 2
+	zoneProgressHeight = 6
+)
+
+var (
+	freeZoneColor      = color.RGBA{R: 200, G: 200, B: 200, A: 255}
+	contestedZoneColor = color.RGBA{R: 255, G: 140, B: 0, A: 255}
+)
+
+// DrawZone draws the control zone as a translucent area
+// of the holder's color with a bar of the progress
+// till the holder's next point above it.
+//
+// Accepts pointers to the zone, screen and camera objects as arguments.
+func DrawZone(zone *entity.Zone, screen *ebiten.Image, camera *camera.Camera) {
+	zoneColor := zoneColor(zone)
+	fill := color.RGBA{R: zoneColor.R, G: zoneColor.G, B: zoneColor.B, A: zoneAlpha}
+
+	inCamPosition := camera.WorldToScreen(zone.Position)
+	vector.DrawFilledRect(screen, inCamPosition.X, inCamPosition.Y, zone.Size, zone.Size, fill, false)
+	vector.StrokeRect(screen, inCamPosition.X, inCamPosition.Y, zone.Size, zone.Size, zoneBorderWidth, zoneColor, false)
+
+	// draw the progress bar
+	if zone.Holder == 0 {
+		return
+	}
+	y := inCamPosition.Y - zoneProgressHeight*2
+	vector.StrokeRect(screen, inCamPosition.X, y, zone.Size, zoneProgressHeight, 1, zoneColor, false)
+	vector.DrawFilledRect(screen, inCamPosition.X, y, zone.Size*zone.Progress, zoneProgressHeight, zoneColor, false)
+}
+
+// drawZoneHolders draws the holders of the control zones
+// under the state of the match.
+//
+// Accepts the zones and a pointer to the screen.
+func drawZoneHolders(zones []*entity.Zone, screen *ebiten.Image) {
+	holders := make([]string, len(zones))
+	for i, zone := range zones {
+		switch {
+		case zone.Contested:
+			holders[i] = "CONTESTED"
+		case zone.Holder != 0:
+			holders[i] = game.TeamName(zone.Holder)
+		default:
+			holders[i] = "FREE"
+		}
+	}
+
+	drawCenteredText(screen, "HILLS: "+strings.Join(holders, "  "), matchStatusY*3, color.White)
+}
+
+// zoneColor returns the color of the zone's holder,
+// the zone is orange while it is contested
+// and gray while nobody holds it.
+//
+// Accepts a pointer to the zone.
+func zoneColor(zone *entity.Zone) color.RGBA {
+	switch {
+	case zone.Contested:
+		return contestedZoneColor
+	case zone.Holder != 0:
+		return game.TeamColor(zone.Holder)
+	default:
+		return freeZoneColor
+	}
+}
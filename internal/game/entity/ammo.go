@@ -0,0 +1,93 @@
+package entity
+
+// Ammo is the ammunition of the square's weapon.
+type Ammo struct {
+	// Magazine is an amount of the rounds in the magazine
+	Magazine int `json:"magazine"`
+	// Reserve is an amount of the spare rounds
+	Reserve int `json:"reserve"`
+}
+
+// Reload starts the reloading of the magazine if it isn't
+// full and there are spare rounds. The reloading takes
+// the weapon's reload time and is interrupted by a shot
+// or by switching the weapon.
+func (s *Square) Reload() {
+	if s.reloading.Active() || s.Weapon.Magazine == 0 ||
+		s.Ammo.Magazine >= s.Weapon.Magazine || s.Ammo.Reserve == 0 {
+		return
+	}
+
+	s.reloading.Start(s.Weapon.ReloadTime)
+	s.ReloadProgress = 0
+}
+
+// Reloading checks if the square reloads its weapon.
+func (s *Square) Reloading() bool {
+	return s.reloading.Active()
+}
+
+// NeedsReload checks if the magazine isn't full
+// and can be filled from the reserve.
+func (s *Square) NeedsReload() bool {
+	return s.Ammo.Magazine < s.Weapon.Magazine && s.Ammo.Reserve > 0
+}
+
+// OutOfAmmo checks if the weapon has no rounds left
+// in the magazine and in the reserve.
+func (s *Square) OutOfAmmo() bool {
+	return !s.hasRound() && s.Ammo.Reserve == 0
+}
+
+// LoadedWeapon finds the weapon the square can switch to
+// which has rounds in the magazine or in the reserve.
+//
+// Returns the kind of the weapon and false if there is no such weapon.
+func (s *Square) LoadedWeapon() (WeaponKind, bool) {
+	for kind := WeaponKind(0); kind < PlayerWeapons; kind++ {
+		ammo := s.stock[kind]
+		if kind != s.WeaponKind && (ammo.Magazine > 0 || ammo.Reserve > 0) {
+			return kind, true
+		}
+	}
+	return 0, false
+}
+
+// RefillAmmo fills the magazines and the reserves of every weapon.
+func (s *Square) RefillAmmo() {
+	s.stopReload()
+	for kind := range s.stock {
+		weapon := NewWeapon(WeaponKind(kind))
+		s.stock[kind] = Ammo{Magazine: weapon.Magazine, Reserve: weapon.Reserve}
+	}
+	s.Ammo = s.stock[s.WeaponKind]
+}
+
+// RefillReserve fills the reserves of every weapon
+// keeping the rounds in the magazines.
+func (s *Square) RefillReserve() {
+	for kind := range s.stock {
+		s.stock[kind].Reserve = NewWeapon(WeaponKind(kind)).Reserve
+	}
+	s.Ammo.Reserve = s.Weapon.Reserve
+}
+
+// hasRound checks if the weapon has a round to shoot,
+// the weapons without the magazine always have it.
+func (s *Square) hasRound() bool {
+	return s.Weapon.Magazine == 0 || s.Ammo.Magazine > 0
+}
+
+// finishReload moves the spare rounds to the magazine.
+func (s *Square) finishReload() {
+	rounds := min(s.Weapon.Magazine-s.Ammo.Magazine, s.Ammo.Reserve)
+	s.Ammo.Magazine += rounds
+	s.Ammo.Reserve -= rounds
+	s.ReloadProgress = 0
+}
+
+// stopReload interrupts the reloading.
+func (s *Square) stopReload() {
+	s.reloading.Stop()
+	s.ReloadProgress = 0
+}
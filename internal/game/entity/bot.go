@@ -1,31 +1,32 @@
 package entity
 
 import (
+	"math/rand"
 	"online_shooter/internal/config"
 	"online_shooter/internal/utils"
 )
 
-const msToReload = 500
-
 // NewBot creates and initializes
 // new bot square instance with default parameters.
 //
-// Accepts an id as an argument.
+// Accepts an id and a pointer to the game's random source.
 //
 // Returns pointer to the created bot square.
-func NewBot(id int64) *Square {
+func NewBot(id int64, random *rand.Rand) *Square {
 	// create and init instance
 	b := &Square{
 		Id:         id,
-		Health:     100,
+		Health:     config.SquareHealth(),
 		Speed:      config.SquareSpeed(),
 		Size:       config.SquareSize(),
-		Color:      utils.RandomBrightColor(),
+		Color:      utils.RandomBrightColor(random),
 		CanShoot:   true,
 		Vulnerable: true,
+		Stats:      DefaultStats(),
 		IsBot:      true,
-		ShotCh:     make(chan struct{}),
 	}
+	b.Equip(b.Stats.Weapon)
+	b.RefillAmmo()
 
 	return b
 }
@@ -2,16 +2,22 @@ package entity
 
 import (
 	"online_shooter/internal/game/geometry"
-	"sync"
 )
 
 type Bullet struct {
-	sync.RWMutex
 	Position geometry.Point
 	Vector   geometry.Vector
 	Size     float32
 	Speed    float32
 	Damage   int32
+	// Range is a distance the bullet flies,
+	// zero means it flies till it hits something
+	Range float32
+	// Burns shows if the bullet sets the hit square on fire
+	Burns bool
+
+	// distance the bullet has flown
+	traveled float32
 }
 
 // UpdateBulletPosition updates bullet position
@@ -21,4 +27,10 @@ type Bullet struct {
 func (b *Bullet) UpdateBulletPosition(deltaTime float32) {
 	b.Position.X += b.Vector.X * b.Speed * deltaTime
 	b.Position.Y += b.Vector.Y * b.Speed * deltaTime
+	b.traveled += b.Speed * deltaTime
+}
+
+// OutOfRange checks if the bullet has flown its range.
+func (b *Bullet) OutOfRange() bool {
+	return b.Range > 0 && b.traveled >= b.Range
 }
@@ -0,0 +1,100 @@
+package entity
+
+import (
+	"online_shooter/internal/game/timer"
+	"time"
+)
+
+// AssistWindow is a time the damage done to the square
+// counts as an assist if the square is killed by another one
+const AssistWindow = 5 * time.Second
+
+// CombatStats are the square's stats of the fights
+// collected during the round.
+type CombatStats struct {
+	// DamageDealt is the damage done to the enemies
+	// and DamageTaken is the damage taken from anything,
+	// the damage beyond the health isn't counted
+	DamageDealt int32
+	DamageTaken int32
+	Assists     uint16
+	// Streak is an amount of the kills since the last death
+	// and BestStreak is the longest streak of the round
+	Streak     uint16
+	BestStreak uint16
+	// ShotsFired and ShotsHit are the amounts of the bullets
+	// fired by the square and the ones which have hit a square
+	ShotsFired uint32
+	ShotsHit   uint32
+}
+
+// Accuracy counts the part of the fired bullets which have hit.
+//
+// Returns the accuracy from zero to one, zero if nothing is fired.
+func (c CombatStats) Accuracy() float32 {
+	if c.ShotsFired == 0 {
+		return 0
+	}
+	return float32(c.ShotsHit) / float32(c.ShotsFired)
+}
+
+// attacker is the square which has recently damaged the Square.
+type attacker struct {
+	id int64
+	// timer of the assist window since the last damage
+	window timer.Timer
+}
+
+// Attackers returns the ids of the squares which have damaged
+// the Square during the assist window in the order of the first damage.
+func (s *Square) Attackers() []int64 {
+	ids := make([]int64, 0, len(s.attackers))
+	for _, a := range s.attackers {
+		ids = append(ids, a.id)
+	}
+	return ids
+}
+
+// addAttacker remembers the square which has damaged
+// the Square and restarts its assist window.
+//
+// Accepts an id of the square.
+func (s *Square) addAttacker(id int64) {
+	for i := range s.attackers {
+		if s.attackers[i].id == id {
+			s.attackers[i].window.Start(AssistWindow)
+			return
+		}
+	}
+
+	a := attacker{id: id}
+	a.window.Start(AssistWindow)
+	s.attackers = append(s.attackers, a)
+}
+
+// updateAttackers advances the assist windows
+// and forgets the attackers whose window is over.
+//
+// Accepts the elapsed game time.
+func (s *Square) updateAttackers(dt time.Duration) {
+	kept := s.attackers[:0]
+	for _, a := range s.attackers {
+		if !a.window.Advance(dt) {
+			kept = append(kept, a)
+		}
+	}
+	s.attackers = kept
+}
+
+// countKill counts the kill of the enemy and continues the streak.
+func (s *Square) countKill() {
+	s.Kills++
+	s.Combat.Streak++
+	s.Combat.BestStreak = max(s.Combat.BestStreak, s.Combat.Streak)
+}
+
+// countDeath counts the death of the Square and ends its streak.
+func (s *Square) countDeath() {
+	s.Deaths++
+	s.Combat.Streak = 0
+}
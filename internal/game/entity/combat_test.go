@@ -0,0 +1,78 @@
+package entity
+
+import (
+	"online_shooter/internal/game/geometry"
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestKillStreakEndsOnDeath(t *testing.T) {
+	killer := NewBot(1, random)
+	for i := 0; i < 3; i++ {
+		NewBot(int64(i+2), random).GetDamage(&Bullet{Damage: 1000}, killer)
+	}
+	if killer.Kills != 3 || killer.Combat.Streak != 3 {
+		t.Fatalf("got %d kills and streak %d, want 3 of both", killer.Kills, killer.Combat.Streak)
+	}
+
+	killer.TakeDamage(1000)
+	if killer.Combat.Streak != 0 || killer.Combat.BestStreak != 3 {
+		t.Fatalf("got streak %d and best %d, want the streak ended and the best kept", killer.Combat.Streak, killer.Combat.BestStreak)
+	}
+}
+
+func TestDamageBeyondHealthNotCounted(t *testing.T) {
+	s := NewBot(1, random)
+	shooter := NewBot(2, random)
+	s.Health = 10
+
+	s.GetDamage(&Bullet{Damage: 25}, shooter)
+	if s.Combat.DamageTaken != 10 || shooter.Combat.DamageDealt != 10 {
+		t.Fatalf("got %d taken and %d dealt, want 10 of both", s.Combat.DamageTaken, shooter.Combat.DamageDealt)
+	}
+}
+
+func TestAccuracyCountsBullets(t *testing.T) {
+	shooter := NewBot(1, random)
+	shooter.Equip(Shotgun)
+	shooter.Shoot(geometry.Point{X: 1000, Y: 1000}, random)
+
+	NewBot(2, random).GetDamage(shooter.Bullets[0], shooter)
+	want := 1 / float32(shooter.Weapon.Pellets)
+	if got := shooter.Combat.Accuracy(); got != want {
+		t.Fatalf("got accuracy %v, want %v for one hit pellet", got, want)
+	}
+}
+
+func TestAttackerForgottenAfterAssistWindow(t *testing.T) {
+	s := NewBot(1, random)
+	s.GetDamage(&Bullet{Damage: 1}, NewBot(2, random))
+	s.UpdateTimers(AssistWindow / 2)
+	s.GetDamage(&Bullet{Damage: 1}, NewBot(3, random))
+
+	s.UpdateTimers(AssistWindow / 2)
+	if got := s.Attackers(); !slices.Equal(got, []int64{3}) {
+		t.Fatalf("got attackers %v, want only the recent one", got)
+	}
+
+	s.UpdateTimers(AssistWindow/2 + time.Millisecond)
+	if got := s.Attackers(); len(got) != 0 {
+		t.Fatalf("got attackers %v, want none", got)
+	}
+}
+
+func TestAccuracyCountsOnlyEnemyHits(t *testing.T) {
+	shooter := NewBot(1, random)
+	shooter.Team = 1
+	teammate := NewBot(2, random)
+	teammate.Team = 1
+	shielded := NewBot(3, random)
+	shielded.AddEffect(ShieldEffect)
+
+	teammate.GetDamage(&Bullet{Damage: 1}, shooter)
+	shielded.GetDamage(&Bullet{Damage: 1}, shooter)
+	if shooter.Combat.ShotsHit != 0 {
+		t.Fatalf("got %d hits, want the teammate and the shield not counted", shooter.Combat.ShotsHit)
+	}
+}
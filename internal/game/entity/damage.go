@@ -0,0 +1,51 @@
+package entity
+
+import "time"
+
+// damage passes the damage through the pipeline:
+// the armor absorbs the damage first and the rest
+// is taken from the health. The damage stops the
+// regeneration of the health for a while.
+//
+// Accepts the amount of the damage.
+//
+// Returns the damage taken by the armor and the health
+// which doesn't count the damage beyond the health.
+func (s *Square) damage(damage int32) int32 {
+	absorbed := min(s.Armor, damage)
+	s.Armor -= absorbed
+	lost := min(max(s.Health, 0), damage-absorbed)
+	s.Health -= damage - absorbed
+
+	s.regeneration.Stop()
+	s.outOfCombat.Start(s.Stats.Damage.RegenerationDelay)
+
+	s.Combat.DamageTaken += absorbed + lost
+	return absorbed + lost
+}
+
+// regenerate restores the health of the square which
+// hasn't been damaged for the regeneration delay
+// by one point at a time up to its base health.
+//
+// Accepts the elapsed game time.
+func (s *Square) regenerate(dt time.Duration) {
+	rate := s.Stats.Damage.Regeneration
+	if rate <= 0 || s.outOfCombat.Active() && !s.outOfCombat.Advance(dt) {
+		return
+	}
+
+	if s.Health >= s.Stats.Health {
+		s.regeneration.Stop()
+		return
+	}
+
+	period := time.Second / time.Duration(rate)
+	if !s.regeneration.Active() {
+		s.regeneration.Start(period)
+	}
+	if s.regeneration.Advance(dt) {
+		s.Health++
+		s.regeneration.Start(period)
+	}
+}
@@ -0,0 +1,62 @@
+package entity
+
+import (
+	"online_shooter/internal/config"
+	"testing"
+	"time"
+)
+
+func TestArmorAbsorbsDamage(t *testing.T) {
+	s := NewBot(1, random)
+	s.Stats.Armor = 30
+	s.ApplyStats()
+
+	s.GetDamage(&Bullet{Damage: 20}, NewBot(2, random))
+	if s.Armor != 10 || s.Health != config.SquareHealth() {
+		t.Fatalf("got armor %d and health %d, want the armor to absorb the damage", s.Armor, s.Health)
+	}
+
+	s.GetDamage(&Bullet{Damage: 20}, NewBot(2, random))
+	if s.Armor != 0 || s.Health != config.SquareHealth()-10 {
+		t.Fatalf("got armor %d and health %d, want the rest of the damage taken from the health", s.Armor, s.Health)
+	}
+}
+
+func TestRegenerationOutOfCombat(t *testing.T) {
+	s := NewBot(1, random)
+	s.Stats.Damage.Regeneration = 10
+	s.Stats.Damage.RegenerationDelay = time.Second
+	s.ApplyStats()
+	s.TakeDamage(20)
+
+	// the health doesn't regenerate till the delay is over
+	s.UpdateTimers(time.Second - time.Millisecond)
+	if s.Health != config.SquareHealth()-20 {
+		t.Fatalf("got health %d, want no regeneration in combat", s.Health)
+	}
+
+	for i := 0; i < 10; i++ {
+		s.UpdateTimers(100 * time.Millisecond)
+	}
+	if s.Health != config.SquareHealth()-10 {
+		t.Fatalf("got health %d, want 10 points regenerated in a second", s.Health)
+	}
+
+	// the regeneration stops at the base health
+	for i := 0; i < 30; i++ {
+		s.UpdateTimers(100 * time.Millisecond)
+	}
+	if s.Health != config.SquareHealth() {
+		t.Fatalf("got health %d, want the base health", s.Health)
+	}
+}
+
+func TestHitSlowdownTurnedOff(t *testing.T) {
+	s := NewBot(1, random)
+	s.Stats.Damage.HitSlowdown = 0
+	s.GetDamage(&Bullet{Damage: 1}, NewBot(2, random))
+
+	if s.HasEffect(SlowEffect) || s.EffectiveSpeed() != s.Speed {
+		t.Fatalf("got effective speed %v, want no slowdown", s.EffectiveSpeed())
+	}
+}
@@ -0,0 +1,67 @@
+package entity
+
+import (
+	"online_shooter/internal/game/geometry"
+	"time"
+)
+
+const (
+	// dashSpeedFactor is how many times the dash is faster than the square
+	dashSpeedFactor = 3
+	dashDuration    = 150 * time.Millisecond
+	// DashCooldown is a time after the dash till the next one
+	DashCooldown = 2 * time.Second
+)
+
+// Dash starts a short burst of speed in the direction
+// of the movement. The dashing square keeps the direction
+// till the dash is over and can't dash again till
+// the cooldown is over.
+//
+// Accepts the moving vector of the square.
+//
+// Returns true if the square has dashed.
+func (s *Square) Dash(v geometry.Vector) bool {
+	// the square dashes only while it is moving
+	if !s.DashReady() || v.X == 0 && v.Y == 0 {
+		return false
+	}
+
+	v.Normalize()
+	s.dashVector = v
+	s.dashing.Start(dashDuration)
+	s.dashCooldown.Start(DashCooldown)
+	s.DashCooldown = 1
+
+	return true
+}
+
+// Dashing checks if the square is dashing.
+func (s *Square) Dashing() bool {
+	return s.dashing.Active()
+}
+
+// DashReady checks if the square can dash.
+func (s *Square) DashReady() bool {
+	return !s.dashCooldown.Active()
+}
+
+// updateDash advances the dash's timers
+// and counts the part of the cooldown left.
+//
+// Accepts the elapsed game time.
+func (s *Square) updateDash(dt time.Duration) {
+	s.dashing.Advance(dt)
+	if s.dashCooldown.Advance(dt) {
+		s.DashCooldown = 0
+	} else if s.dashCooldown.Active() {
+		s.DashCooldown = float32(s.dashCooldown.Remaining()) / float32(DashCooldown)
+	}
+}
+
+// stopDash stops the dash and its cooldown.
+func (s *Square) stopDash() {
+	s.dashing.Stop()
+	s.dashCooldown.Stop()
+	s.DashCooldown = 0
+}
@@ -0,0 +1,53 @@
+package entity
+
+import "time"
+
+// Die takes the killed Square out of the game till it respawns:
+// it can't move and shoot and isn't hit anymore.
+// The Square waits for the delay showing its killer.
+//
+// Accepts a pointer to the killer which is nil if the Square
+// is killed not by a square and the delay of the respawn.
+func (s *Square) Die(killer *Square, delay time.Duration) {
+	s.Dead = true
+	s.Killer = 0
+	if killer != nil && killer != s {
+		s.Killer = killer.Id
+	}
+	s.Health = 0
+	s.Armor = 0
+	s.ClearBullets()
+	s.ClearEffects()
+	s.stopDash()
+	s.cooldown.Stop()
+	s.stopReload()
+	s.CanShoot = false
+
+	s.attackers = s.attackers[:0]
+
+	s.respawn.Start(delay)
+	s.RespawnIn = delay
+}
+
+// UpdateRespawn counts down the respawn of the dead Square.
+//
+// Accepts the elapsed game time.
+//
+// Returns true if it is time to respawn.
+func (s *Square) UpdateRespawn(dt time.Duration) bool {
+	if s.respawn.Advance(dt) {
+		return true
+	}
+	s.RespawnIn = s.respawn.Remaining()
+
+	return false
+}
+
+// revive clears the death of the Square.
+func (s *Square) revive() {
+	s.Dead = false
+	s.Killer = 0
+	s.RespawnIn = 0
+	s.respawn.Stop()
+	s.attackers = s.attackers[:0]
+}
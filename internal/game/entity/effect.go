@@ -0,0 +1,185 @@
+package entity
+
+import "time"
+
+// EffectKind is a kind of the temporary status effect
+type EffectKind int
+
+// kinds of the status effects
+const (
+	// ShieldEffect blocks the bullets
+	ShieldEffect EffectKind = iota
+	// DoubleDamageEffect doubles the damage of the bullets
+	DoubleDamageEffect
+	// RapidFireEffect halves the time between the shots
+	RapidFireEffect
+	// SpeedEffect speeds the square up
+	SpeedEffect
+	// SlowEffect slows the square down, every stack
+	// takes the effect's strength from the speed
+	SlowEffect
+	// BurnEffect damages the square every second, every stack burns more
+	BurnEffect
+)
+
+const (
+	speedEffectFactor  = 1.5
+	damageEffectFactor = 2
+	fireEffectFactor   = 2
+	// burnDamage is a damage of one stack of the burn every burnInterval
+	burnDamage   = 5
+	burnInterval = time.Second
+)
+
+// effectRule describes how long the effect lasts and how
+// many times it stacks. The effect which doesn't stack
+// is refreshed when the square gets it again,
+// the stackable effect gets a stack and is refreshed.
+type effectRule struct {
+	name      string
+	duration  time.Duration
+	maxStacks int
+}
+
+var effectRules = map[EffectKind]effectRule{
+	ShieldEffect:       {name: "SHIELD", duration: 5 * time.Second, maxStacks: 1},
+	DoubleDamageEffect: {name: "DOUBLE DAMAGE", duration: 8 * time.Second, maxStacks: 1},
+	RapidFireEffect:    {name: "RAPID FIRE", duration: 8 * time.Second, maxStacks: 1},
+	SpeedEffect:        {name: "SPEED", duration: 5 * time.Second, maxStacks: 1},
+	SlowEffect:         {name: "SLOW", duration: DefaultSlowdownRecovery, maxStacks: 3},
+	BurnEffect:         {name: "BURN", duration: 3 * time.Second, maxStacks: 3},
+}
+
+// Effect is a temporary status effect of the square.
+type Effect struct {
+	Kind      EffectKind    `json:"kind"`
+	Stacks    int           `json:"stacks"`
+	Remaining time.Duration `json:"remaining"`
+	// Strength is a part of the speed every stack of the slow takes
+	Strength float32 `json:"strength,omitempty"`
+}
+
+// Name returns the name of the effect to show it to the player.
+func (e Effect) Name() string {
+	return effectRules[e.Kind].name
+}
+
+// AddEffect gives the effect to the square by the effect's rule:
+// the effect is refreshed and gets a stack if it is stackable.
+//
+// Accepts the kind of the effect.
+func (s *Square) AddEffect(kind EffectKind) {
+	s.addEffect(kind, effectRules[kind].duration)
+}
+
+// addEffect gives the effect lasting for the duration
+// instead of the rule's one.
+//
+// Accepts the kind of the effect and its duration.
+//
+// Returns a pointer to the square's effect.
+func (s *Square) addEffect(kind EffectKind, duration time.Duration) *Effect {
+	for i := range s.Effects {
+		effect := &s.Effects[i]
+		if effect.Kind == kind {
+			effect.Stacks = min(effect.Stacks+1, effectRules[kind].maxStacks)
+			effect.Remaining = duration
+			return effect
+		}
+	}
+
+	s.Effects = append(s.Effects, Effect{Kind: kind, Stacks: 1, Remaining: duration})
+	if kind == BurnEffect {
+		s.burn.Start(burnInterval)
+	}
+	return &s.Effects[len(s.Effects)-1]
+}
+
+// HasEffect checks if the square has the effect.
+//
+// Accepts the kind of the effect.
+func (s *Square) HasEffect(kind EffectKind) bool {
+	return s.effectStacks(kind) > 0
+}
+
+// UpdateEffects counts down the effects, removes the expired ones
+// and burns the square if it has the burn effect.
+//
+// Accepts the elapsed game time.
+//
+// Returns true if the square is burned to death.
+func (s *Square) UpdateEffects(dt time.Duration) bool {
+	// the burn is counted before it may expire on this tick
+	burned := false
+	if stacks := s.effectStacks(BurnEffect); stacks > 0 && s.burn.Advance(dt) {
+		s.burn.Start(burnInterval)
+		burned = s.TakeDamage(int32(burnDamage * stacks))
+	}
+
+	effects := s.Effects[:0]
+	for _, effect := range s.Effects {
+		effect.Remaining -= dt
+		if effect.Remaining > 0 {
+			effects = append(effects, effect)
+		}
+	}
+	s.Effects = effects
+
+	if !s.HasEffect(BurnEffect) {
+		s.burn.Stop()
+	}
+
+	return burned
+}
+
+// ClearEffects removes every effect of the square.
+func (s *Square) ClearEffects() {
+	s.Effects = nil
+	s.burn.Stop()
+}
+
+// EffectiveSpeed counts the square's speed
+// changed by the speed and slow effects.
+func (s *Square) EffectiveSpeed() float32 {
+	speed := s.Speed
+	if s.HasEffect(SpeedEffect) {
+		speed *= speedEffectFactor
+	}
+	for _, effect := range s.Effects {
+		if effect.Kind == SlowEffect {
+			speed *= max(0, 1-effect.Strength*float32(effect.Stacks))
+		}
+	}
+	return speed
+}
+
+// effectiveDamage counts the damage of the square's bullets
+// changed by the double damage effect.
+func (s *Square) effectiveDamage() int32 {
+	if s.HasEffect(DoubleDamageEffect) {
+		return s.Weapon.Damage * damageEffectFactor
+	}
+	return s.Weapon.Damage
+}
+
+// effectiveFireRate counts the time between the square's shots
+// changed by the rapid fire effect.
+func (s *Square) effectiveFireRate() time.Duration {
+	if s.HasEffect(RapidFireEffect) {
+		return s.Weapon.FireRate / fireEffectFactor
+	}
+	return s.Weapon.FireRate
+}
+
+// effectStacks returns the stacks of the square's effect
+// or zero if the square doesn't have it.
+//
+// Accepts the kind of the effect.
+func (s *Square) effectStacks(kind EffectKind) int {
+	for _, effect := range s.Effects {
+		if effect.Kind == kind {
+			return effect.Stacks
+		}
+	}
+	return 0
+}
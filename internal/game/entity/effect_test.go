@@ -0,0 +1,63 @@
+package entity
+
+import (
+	"online_shooter/internal/config"
+	"testing"
+	"time"
+)
+
+func TestSlowStacksAndWearsOff(t *testing.T) {
+	s := NewBot(1, random)
+	shooter := NewBot(2, random)
+	for i := 0; i < 5; i++ {
+		s.GetDamage(&Bullet{Damage: 1}, shooter)
+	}
+
+	// the slow stacks up to the limit and doesn't change the base speed
+	want := s.Speed * (1 - s.Stats.Damage.HitSlowdown*3)
+	if s.EffectiveSpeed() != want || s.Speed != config.SquareSpeed() {
+		t.Fatalf("got effective speed %v and speed %v, want %v", s.EffectiveSpeed(), s.Speed, want)
+	}
+
+	s.UpdateEffects(s.Stats.Damage.SlowdownRecovery)
+	if s.HasEffect(SlowEffect) || s.EffectiveSpeed() != s.Speed {
+		t.Fatalf("got effective speed %v, want the slow to wear off", s.EffectiveSpeed())
+	}
+}
+
+func TestPowerUpRefreshes(t *testing.T) {
+	s := NewBot(1, random)
+	s.AddEffect(RapidFireEffect)
+	s.UpdateEffects(time.Second)
+	s.AddEffect(RapidFireEffect)
+
+	effect := s.Effects[0]
+	if len(s.Effects) != 1 || effect.Stacks != 1 || effect.Remaining != effectRules[RapidFireEffect].duration {
+		t.Fatalf("got effects %+v, want one refreshed rapid fire", s.Effects)
+	}
+	if s.effectiveFireRate() != s.Weapon.FireRate/fireEffectFactor {
+		t.Fatalf("got fire rate %v, want the rapid fire", s.effectiveFireRate())
+	}
+}
+
+func TestShieldBlocksBullets(t *testing.T) {
+	s := NewBot(1, random)
+	s.AddEffect(ShieldEffect)
+
+	if s.GetDamage(&Bullet{Damage: s.Health}, NewBot(2, random)) || s.Health != config.SquareHealth() {
+		t.Fatalf("got health %d, want the shield to block the bullet", s.Health)
+	}
+}
+
+func TestBurnKills(t *testing.T) {
+	s := NewBot(1, random)
+	s.Health = burnDamage
+	s.GetDamage(&Bullet{Damage: 0, Burns: true}, NewBot(2, random))
+
+	if s.UpdateEffects(burnInterval - time.Millisecond) {
+		t.Fatal("square burned too early")
+	}
+	if !s.UpdateEffects(time.Millisecond) {
+		t.Fatalf("got health %d, want the square to burn to death", s.Health)
+	}
+}
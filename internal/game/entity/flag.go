@@ -0,0 +1,111 @@
+package entity
+
+import (
+	"online_shooter/internal/game/geometry"
+	"online_shooter/internal/game/timer"
+	"time"
+)
+
+const (
+	flagReturnSeconds = 15
+	flagSizeFactor    = 0.5
+	baseSizeFactor    = 3
+)
+
+// Flag is a flag of the team in the capture-the-flag mode.
+// The flag stands in the center of the team's base zone
+// till an enemy takes it. The dropped flag returns
+// to the base after a timeout or when a teammate touches it.
+type Flag struct {
+	Team     int            `json:"team"`
+	Position geometry.Point `json:"position"`
+	Size     float32        `json:"size"`
+	// Base is the team's base zone, the flag is
+	// captured by bringing it to the enemy's base
+	Base    geometry.Rect `json:"base"`
+	Carrier int64         `json:"carrier,omitempty"`
+	AtBase  bool          `json:"at_base"`
+
+	// timer of the dropped flag's return
+	returnTimer timer.Timer
+}
+
+// NewFlag creates a flag standing in the center
+// of the team's base zone.
+//
+// Accepts the team, the center of the base zone
+// and a size of the squares the zone is measured with.
+//
+// Returns a pointer to the created flag.
+func NewFlag(team int, center geometry.Point, squareSize float32) *Flag {
+	baseSize := squareSize * baseSizeFactor
+	f := &Flag{
+		Team: team,
+		Size: squareSize * flagSizeFactor,
+		Base: geometry.Rect{
+			X:      center.X - baseSize/2,
+			Y:      center.Y - baseSize/2,
+			Width:  baseSize,
+			Height: baseSize,
+		},
+	}
+	f.Return()
+
+	return f
+}
+
+// Take gives the flag to the carrier.
+//
+// Accepts a pointer to the square taking the flag.
+func (f *Flag) Take(carrier *Square) {
+	f.Carrier = carrier.Id
+	f.AtBase = false
+	f.returnTimer.Stop()
+	f.Follow(carrier)
+}
+
+// Follow moves the flag together with its carrier.
+//
+// Accepts a pointer to the carrier.
+func (f *Flag) Follow(carrier *Square) {
+	f.Position = geometry.Point{
+		X: carrier.Position.X + (carrier.Size-f.Size)/2,
+		Y: carrier.Position.Y + (carrier.Size-f.Size)/2,
+	}
+}
+
+// Drop drops the flag where the carrier was
+// and starts the timer of its return.
+func (f *Flag) Drop() {
+	f.Carrier = 0
+	f.returnTimer.Start(flagReturnSeconds * time.Second)
+}
+
+// Return returns the flag to the center of its base zone.
+func (f *Flag) Return() {
+	f.Carrier = 0
+	f.AtBase = true
+	f.returnTimer.Stop()
+	f.Position = geometry.Point{
+		X: f.Base.X + (f.Base.Width-f.Size)/2,
+		Y: f.Base.Y + (f.Base.Height-f.Size)/2,
+	}
+}
+
+// UpdateTimers advances the timer of the dropped flag's
+// return and returns the flag when the time is over.
+//
+// Accepts the elapsed game time.
+func (f *Flag) UpdateTimers(dt time.Duration) {
+	if f.returnTimer.Advance(dt) {
+		f.Return()
+	}
+}
+
+// Clone creates a copy of the flag.
+//
+// Returns a pointer to the copy.
+func (f *Flag) Clone() *Flag {
+	c := *f
+	return &c
+}
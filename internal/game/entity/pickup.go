@@ -0,0 +1,134 @@
+package entity
+
+import (
+	"online_shooter/internal/config"
+	"online_shooter/internal/game/geometry"
+	"online_shooter/internal/game/timer"
+	"time"
+)
+
+// PickupKind is a kind of the pickup's effect
+type PickupKind int
+
+// kinds of the pickups
+const (
+	HealthPickup PickupKind = iota
+	AmmoPickup
+	SpeedPickup
+	ShieldPickup
+	DamagePickup
+	RapidFirePickup
+)
+
+const (
+	pickupSizeFactor    = 0.6
+	pickupRespawnPeriod = 15 * time.Second
+	// pickupHealFactor is a part of the max health the pack restores
+	pickupHealFactor = 0.5
+)
+
+// Pickup is an item on the arena which gives its effect
+// to the square touching it: restores the health or the ammo
+// or gives a temporary power-up. The taken pickup
+// appears again at the same place after the respawn period.
+type Pickup struct {
+	Kind     PickupKind     `json:"kind"`
+	Position geometry.Point `json:"position"`
+	Size     float32        `json:"size"`
+	Active   bool           `json:"active"`
+
+	// timer of the taken pickup's respawn
+	respawn timer.Timer
+}
+
+// NewPickup creates an active pickup.
+//
+// Accepts the kind of the pickup and the center of its place.
+//
+// Returns a pointer to the created pickup.
+func NewPickup(kind PickupKind, center geometry.Point) *Pickup {
+	size := config.SquareSize() * pickupSizeFactor
+	return &Pickup{
+		Kind:     kind,
+		Position: geometry.Point{X: center.X - size/2, Y: center.Y - size/2},
+		Size:     size,
+		Active:   true,
+	}
+}
+
+// Apply gives the pickup's effect to the square and
+// starts the respawn of the pickup. The square doesn't
+// take the pickup if it doesn't need the effect.
+//
+// Accepts a pointer to the square touching the pickup.
+//
+// Returns true if the square has taken the pickup.
+func (p *Pickup) Apply(square *Square) bool {
+	if !p.Active || !p.Useful(square) {
+		return false
+	}
+
+	switch p.Kind {
+	case HealthPickup:
+		maxHealth := config.SquareHealth()
+		square.Health = min(square.Health+int32(float32(maxHealth)*pickupHealFactor), maxHealth)
+	case AmmoPickup:
+		square.RefillReserve()
+	case SpeedPickup:
+		square.AddEffect(SpeedEffect)
+	case ShieldPickup:
+		square.AddEffect(ShieldEffect)
+	case DamagePickup:
+		square.AddEffect(DoubleDamageEffect)
+	case RapidFirePickup:
+		square.AddEffect(RapidFireEffect)
+	}
+
+	p.Active = false
+	p.respawn.Start(pickupRespawnPeriod)
+
+	return true
+}
+
+// Useful checks if the square needs the pickup's effect.
+//
+// Accepts a pointer to the square.
+func (p *Pickup) Useful(square *Square) bool {
+	switch p.Kind {
+	case HealthPickup:
+		return square.Health < config.SquareHealth()
+	case AmmoPickup:
+		return square.Weapon.Magazine > 0 && square.Ammo.Reserve < square.Weapon.Reserve
+	default:
+		return true
+	}
+}
+
+// UpdateTimers advances the respawn timer
+// and returns the taken pickup when its time is over.
+//
+// Accepts the elapsed game time.
+func (p *Pickup) UpdateTimers(dt time.Duration) {
+	if p.respawn.Advance(dt) {
+		p.Active = true
+	}
+}
+
+// Reset returns the pickup to the arena.
+func (p *Pickup) Reset() {
+	p.respawn.Stop()
+	p.Active = true
+}
+
+// Center returns the center of the pickup.
+func (p *Pickup) Center() geometry.Point {
+	return geometry.Point{X: p.Position.X + p.Size/2, Y: p.Position.Y + p.Size/2}
+}
+
+// Clone creates a copy of the pickup.
+//
+// Returns a pointer to the copy.
+func (p *Pickup) Clone() *Pickup {
+	c := *p
+	return &c
+}
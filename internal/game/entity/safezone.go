@@ -0,0 +1,39 @@
+package entity
+
+import (
+	"online_shooter/internal/game/geometry"
+	"time"
+)
+
+// SafeZone is the safe circle of the battle royale.
+// The circle waits and then shrinks to the next one
+// in phases, the squares outside it take damage.
+type SafeZone struct {
+	Center     geometry.Point `json:"center"`
+	Radius     float32        `json:"radius"`
+	NextCenter geometry.Point `json:"next_center"`
+	NextRadius float32        `json:"next_radius"`
+	Shrinking  bool           `json:"shrinking,omitempty"`
+	// Remaining is a time till the circle starts or ends
+	// shrinking, it is zero after the last phase
+	Remaining time.Duration `json:"remaining"`
+}
+
+// Contains checks if the center of a square
+// object is inside the circle.
+//
+// Accepts the object's position and size.
+//
+// Returns true if the object is safe, otherwise false.
+func (z *SafeZone) Contains(position geometry.Point, size float32) bool {
+	center := geometry.Point{X: position.X + size/2, Y: position.Y + size/2}
+	return geometry.GetDistanceBetweenTwoPoints(center, z.Center) <= z.Radius
+}
+
+// Clone creates a copy of the safe zone.
+//
+// Returns a pointer to the copy.
+func (z *SafeZone) Clone() *SafeZone {
+	c := *z
+	return &c
+}
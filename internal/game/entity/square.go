@@ -1,97 +1,190 @@
 package entity
 
 import (
-	"context"
-	"github.com/gorilla/websocket"
+	"github.com/chewxy/math32"
 	"image/color"
+	"math/rand"
 	"online_shooter/internal/config"
 	"online_shooter/internal/game/geometry"
-	"online_shooter/internal/utils"
-	"sync"
+	"online_shooter/internal/game/timer"
 	"time"
 )
 
-const (
-	bulletsAmount          = 3
-	changeColorMs          = 250
-	invulnerabilitySeconds = 3
-)
+const invulnerabilitySeconds = 3
 
 type Square struct {
-	Id           int64           `json:"id"`
-	Conn         *websocket.Conn `json:"-"`
-	sync.RWMutex `json:"-"`
-	Position     geometry.Point         `json:"position"`
-	Spawn        geometry.Point         `json:"-"`
-	Health       int32                  `json:"health"`
-	Speed        float32                `json:"speed"`
-	Size         float32                `json:"size"`
-	Bullets      [bulletsAmount]*Bullet `json:"bullets"`
-	Kills        uint16                 `json:"kills"`
-	Deaths       uint16                 `json:"deaths"`
-	Vulnerable   bool                   `json:"-"`
-	IsBot        bool                   `json:"is_bot"`
-	CanShoot     bool                   `json:"-"`
-	Color        color.RGBA             `json:"color"`
-	LastUpdate   *time.Time             `json:"-"`
-	ShotCh       chan struct{}          `json:"-"`
+	Id         int64          `json:"id"`
+	Position   geometry.Point `json:"position"`
+	Spawn      geometry.Point `json:"-"`
+	Health     int32          `json:"health"`
+	Armor      int32          `json:"armor,omitempty"`
+	Speed      float32        `json:"speed"`
+	Size       float32        `json:"size"`
+	Bullets    []*Bullet      `json:"bullets"`
+	WeaponKind WeaponKind     `json:"weapon"`
+	Ammo       Ammo           `json:"ammo"`
+	Effects    []Effect       `json:"effects,omitempty"`
+	Kills      uint16         `json:"kills"`
+	Deaths     uint16         `json:"deaths"`
+	Ping       uint16         `json:"ping"`
+	Vulnerable bool           `json:"vulnerable"`
+	IsBot      bool           `json:"is_bot"`
+	Eliminated bool           `json:"eliminated,omitempty"`
+	Dead       bool           `json:"dead,omitempty"`
+	Team       int            `json:"team,omitempty"`
+	CanShoot   bool           `json:"-"`
+	Color      color.RGBA     `json:"color"`
+	LastUpdate *time.Time     `json:"-"`
+	// Weapon is the equipped weapon of the WeaponKind
+	Weapon Weapon `json:"-"`
+	// ReloadProgress is a part of the magazine's reloading
+	// from zero to one, zero means the weapon isn't reloading
+	ReloadProgress float32 `json:"reload_progress,omitempty"`
+	// DashCooldown is a part of the dash's cooldown left
+	// from one to zero, zero means the square can dash
+	DashCooldown float32 `json:"dash_cooldown,omitempty"`
+	// Killer is an id of the square which has killed
+	// the dead square, zero if it has died not by a square
+	Killer int64 `json:"killer,omitempty"`
+	// RespawnIn is a time left till the dead square respawns
+	RespawnIn time.Duration `json:"respawn_in,omitempty"`
+	// Stats are the base stats the square respawns with
+	Stats Stats `json:"-"`
+	// Combat are the stats of the square's fights in the round
+	Combat CombatStats `json:"-"`
+
+	// ammo of the weapons which aren't equipped
+	stock [weaponKinds]Ammo
+
+	// game time timers of the square's effects
+	cooldown        timer.Timer
+	reloading       timer.Timer
+	burn            timer.Timer
+	dashing         timer.Timer
+	dashCooldown    timer.Timer
+	outOfCombat     timer.Timer
+	regeneration    timer.Timer
+	invulnerability timer.Timer
+	respawn         timer.Timer
+
+	// the direction of the dash
+	dashVector geometry.Vector
+	// the squares which have recently damaged the square
+	attackers []attacker
 }
 
 // Move changes the position of the square due to
-// the moving vector.
+// the moving vector. The dashing square moves faster
+// in the direction of the dash.
 //
 // Accepts the moving vector and a delta time correction value.
 func (s *Square) Move(v geometry.Vector, deltaTime float32) {
 	// count new square's position
-	s.Lock()
-	defer s.Unlock()
-	s.Position.X += v.X * s.Speed * deltaTime
-	s.Position.Y += v.Y * s.Speed * deltaTime
+	speed := s.EffectiveSpeed()
+	if s.Dashing() {
+		v = s.dashVector
+		speed *= dashSpeedFactor
+	}
+	s.Position.X += v.X * speed * deltaTime
+	s.Position.Y += v.Y * speed * deltaTime
 }
 
-// Shoot creates new square's shot.
-func (s *Square) Shoot(towards geometry.Point) {
-	s.Lock()
-	defer s.Unlock()
+// Shoot creates new square's shot. The shot takes
+// a round from the magazine and has as many bullets as
+// the weapon's pellets which deviate from the aim
+// by the weapon's spread. The shot interrupts the reloading
+// if the magazine isn't empty, the empty magazine is reloaded.
+//
+// Accepts a point to shoot towards and a pointer
+// to the game's random source.
+func (s *Square) Shoot(towards geometry.Point, random *rand.Rand) {
+	// check if the square can shoot and the weapon is ready
+	if !s.CanShoot || s.cooldown.Active() {
+		return
+	}
 
-	// check if player can shoot
-	if !s.CanShoot {
+	// reload the empty magazine
+	if !s.hasRound() {
+		s.Reload()
 		return
 	}
+	s.stopReload()
+	if s.Weapon.Magazine > 0 {
+		s.Ammo.Magazine--
+	}
 
-	// go through every slot for bullet
-	for i := 0; i < len(s.Bullets); i++ {
-		// if there is an empty slot
+	// put the pellets into the empty slots
+	vector := s.getShotVector(towards)
+	pellets := s.Weapon.Pellets
+	for i := 0; i < len(s.Bullets) && pellets > 0; i++ {
 		if s.Bullets[i] == nil {
-			// create a new bullet
-			s.Bullets[i] = s.createBullet(towards)
+			s.Bullets[i] = s.createBullet(vector, random)
+			pellets--
+		}
+	}
+	for ; pellets > 0; pellets-- {
+		s.Bullets = append(s.Bullets, s.createBullet(vector, random))
+	}
+	s.Combat.ShotsFired += uint32(s.Weapon.Pellets)
 
-			// disable shooting
-			s.CanShoot = false
+	// disable shooting
+	s.CanShoot = false
 
-			// disable invulnerability
-			select {
-			case s.ShotCh <- struct{}{}:
-			default:
-			}
+	// disable invulnerability
+	if !s.Vulnerable {
+		s.restoreVulnerability()
+	}
 
-			// start weapon reloading
-			go func() {
-				s.reloadWeapon()
-			}()
+	// start the cooldown between the shots
+	s.cooldown.Start(s.effectiveFireRate())
+}
 
-			return
-		}
+// Equip gives the square the weapon of the kind. The ammo
+// of the previous weapon is kept till it is equipped again,
+// the bullets in the air keep flying.
+//
+// Accepts the kind of the weapon.
+func (s *Square) Equip(kind WeaponKind) {
+	s.stopReload()
+	s.stock[s.WeaponKind] = s.Ammo
+
+	s.WeaponKind = kind
+	s.Weapon = NewWeapon(kind)
+	s.Ammo = s.stock[kind]
+}
+
+// SwitchWeapon equips the player's chosen weapon.
+// The new weapon has to cool down before the first shot,
+// the modes' own weapons can't be switched.
+//
+// Accepts the kind of the weapon.
+func (s *Square) SwitchWeapon(kind WeaponKind) {
+	if kind == s.WeaponKind || !kind.Selectable() || !s.WeaponKind.Selectable() {
+		return
 	}
+
+	s.Equip(kind)
+	s.CanShoot = false
+	s.cooldown.Start(s.Weapon.FireRate)
 }
 
-// createBullet creates new bullet and adds it
-// to Square's bullets.
+// createBullet creates new bullet of the square's weapon.
 //
-// Accepts a point to shoot towards.
+// Accepts the vector of the shot and a pointer
+// to the game's random source.
 //
 // Returns a pointer to the created Bullet object.
-func (s *Square) createBullet(towards geometry.Point) *Bullet {
+func (s *Square) createBullet(vector geometry.Vector, random *rand.Rand) *Bullet {
+	// deviate the bullet by the weapon's spread
+	if s.Weapon.Spread > 0 {
+		angle := (random.Float32()*2 - 1) * s.Weapon.Spread
+		sin, cos := math32.Sincos(angle)
+		vector = geometry.Vector{
+			X: vector.X*cos - vector.Y*sin,
+			Y: vector.X*sin + vector.Y*cos,
+		}
+	}
+
 	// create and init bullet
 	squareHalf := s.Size / 2
 	bullet := &Bullet{
@@ -99,10 +192,12 @@ func (s *Square) createBullet(towards geometry.Point) *Bullet {
 			X: s.Position.X + squareHalf,
 			Y: s.Position.Y + squareHalf,
 		},
-		Vector: s.getShotVector(towards),
+		Vector: vector,
 		Size:   config.BulletSize(),
-		Speed:  config.BulletSpeed(),
-		Damage: config.BulletDamage(),
+		Speed:  s.Weapon.BulletSpeed,
+		Damage: s.effectiveDamage(),
+		Range:  s.Weapon.Range,
+		Burns:  s.Weapon.Burns,
 	}
 
 	return bullet
@@ -126,32 +221,51 @@ func (s *Square) getShotVector(towards geometry.Point) geometry.Vector {
 	return vector
 }
 
-// reloadWeapon sets the timer for square weapon reloading.
-func (s *Square) reloadWeapon() {
-	// set a timer
-	timer := time.NewTimer(msToReload * time.Millisecond)
-	defer timer.Stop()
+// UpdateTimers advances the square's timers
+// and applies the effects which time is over:
+// finishes the weapon's cooldown, the reloading and the dash,
+// regenerates the health out of combat and restores the vulnerability.
+//
+// Accepts the elapsed game time.
+func (s *Square) UpdateTimers(dt time.Duration) {
+	// set the shoot ability to true if the weapon has cooled down
+	if s.cooldown.Advance(dt) {
+		s.CanShoot = true
+	}
+
+	// fill the magazine if the reloading is over
+	if s.reloading.Advance(dt) {
+		s.finishReload()
+	} else if s.reloading.Active() {
+		s.ReloadProgress = 1 - float32(s.reloading.Remaining())/float32(s.Weapon.ReloadTime)
+	}
 
-	// wait till the timer ends
-	<-timer.C
+	s.updateDash(dt)
+	s.regenerate(dt)
+	s.updateAttackers(dt)
 
-	// set the shoot ability to true
-	s.Lock()
-	defer s.Unlock()
-	s.CanShoot = true
+	// restore the vulnerability if its time is over
+	if s.invulnerability.Advance(dt) {
+		s.restoreVulnerability()
+	}
 }
 
 // UpdateBullets updates all existing Square's bullets'
-// positions.
+// positions and removes the bullets which have flown their range.
 //
 // Accepts a delta time value to correct bullet's speed.
 func (s *Square) UpdateBullets(deltaTime float32) {
 	// go through every bullet
-	for _, b := range s.Bullets {
+	for i, b := range s.Bullets {
 		// if the bullet exists
 		if b != nil {
 			// update it
 			b.UpdateBulletPosition(deltaTime)
+
+			// remove the bullet which has flown its range
+			if b.OutOfRange() {
+				s.RemoveBullet(i)
+			}
 		}
 	}
 }
@@ -164,87 +278,186 @@ func (s *Square) RemoveBullet(index int) {
 	s.Bullets[index] = nil
 }
 
-// GetDamage reduces the health
-// and the speed of the Square that was shot.
+// ClearBullets removes all the Square's bullets.
+func (s *Square) ClearBullets() {
+	for i := range s.Bullets {
+		s.Bullets[i] = nil
+	}
+}
+
+// Clone creates a copy of the square
+// which doesn't share bullets with the original.
+//
+// Returns a pointer to the copy.
+func (s *Square) Clone() *Square {
+	c := *s
+	c.Effects = append([]Effect(nil), s.Effects...)
+	c.attackers = append([]attacker(nil), s.attackers...)
+	c.Bullets = make([]*Bullet, len(s.Bullets))
+	for i, b := range s.Bullets {
+		if b != nil {
+			bullet := *b
+			c.Bullets[i] = &bullet
+		}
+	}
+	return &c
+}
+
+// GetDamage passes the damage of the bullet through the armor
+// to the health of the Square that was shot unless it has the shield
+// and slows it down till it recovers by the Square's damage rules.
+// The hit of an enemy counts for the shooter's accuracy, the damage
+// done to it counts as dealt and makes the shooter an attacker
+// who may get an assist. Killing a teammate isn't counted as a kill.
+// The killed Square is respawned or eliminated by the game.
 //
-// Accepts a pointer to the bullet that damaged the Square
+// Accepts a pointer to the bullet that damaged the Square,
 // and a pointer to the Square that shot.
-func (s *Square) GetDamage(b *Bullet, shooter *Square) {
-	// reduce square's health
-	s.Health -= b.Damage
+//
+// Returns true if the Square is killed.
+func (s *Square) GetDamage(b *Bullet, shooter *Square) bool {
+	// the shield blocks the bullets
+	if s.HasEffect(ShieldEffect) {
+		return false
+	}
+
+	dealt := s.damage(b.Damage)
+	if !s.IsTeammate(shooter) {
+		shooter.Combat.ShotsHit++
+		shooter.Combat.DamageDealt += dealt
+		s.addAttacker(shooter.Id)
+	}
 
 	// check if square should die
 	if s.Health <= 0 {
-		shooter.Kills++
-		s.Deaths++
-		go func() {
-			s.regenerate()
-		}()
+		if !s.IsTeammate(shooter) {
+			shooter.countKill()
+		}
+		s.countDeath()
 
-		return
+		return true
+	}
+
+	// slow the square down for a while and set it on fire
+	if rules := s.Stats.Damage; rules.HitSlowdown > 0 {
+		s.addEffect(SlowEffect, rules.SlowdownRecovery).Strength = rules.HitSlowdown
 	}
+	if b.Burns {
+		s.AddEffect(BurnEffect)
+	}
+
+	return false
+}
+
+// TakeDamage passes the damage through the armor to the health
+// of the Square damaged not by a bullet, for example by the zone.
+//
+// Accepts the amount of the damage.
+//
+// Returns true if the Square is killed.
+func (s *Square) TakeDamage(damage int32) bool {
+	s.damage(damage)
+	if s.Health <= 0 {
+		s.countDeath()
+		return true
+	}
+
+	return false
+}
+
+// IsTeammate checks if the square plays in the same team.
+//
+// Accepts a pointer to the other square.
+//
+// Returns true if both squares are in the same team,
+// false if they are in different teams or play on their own.
+func (s *Square) IsTeammate(other *Square) bool {
+	return s.Team != 0 && s.Team == other.Team
+}
 
-	// reduce square's speed
-	s.Speed -= s.Speed / 10
+// SetColor changes the color of the Square.
+//
+// Accepts the new color.
+func (s *Square) SetColor(c color.RGBA) {
+	s.Color = c
 }
 
-// regenerate moves Square to the respawn point,
+// Respawn brings the Square back to life at the respawn point,
 // updates health and stats data,
-// starts Square's invulnerability timer.
-func (s *Square) regenerate() {
+// starts Square's invulnerability time.
+//
+// Accepts the point to respawn at.
+func (s *Square) Respawn(position geometry.Point) {
+	s.revive()
+
 	// update square's stats
-	s.Lock()
-	s.Health = config.SquareHealth()
-	s.Speed = config.SquareSpeed()
-	s.Size = config.SquareSize()
+	s.ApplyStats()
 
-	// return the square to its spawn point
-	s.Position = s.Spawn
+	// move the square to the respawn point
+	s.Position = position
 
 	// set the square's vulnerability to false for some time
 	s.Vulnerable = false
+	s.invulnerability.Start(invulnerabilitySeconds * time.Second)
+}
 
-	// save the native color of the square
-	nativeColor := s.Color
-	s.Unlock()
-
-	// create a new context to control the invulnerability time
-	ctx, cancel := context.WithTimeout(context.Background(), invulnerabilitySeconds*time.Second)
-	defer cancel()
-
-	// create a ticker to change colors during invulnerability
-	ticker := time.NewTicker(changeColorMs * time.Millisecond)
-	defer ticker.Stop()
-
-	for {
-		select {
-		case <-ctx.Done():
-			// set the square's vulnerability to true
-			// set the native color to the square
-			s.restoreVulnerability(nativeColor)
-			return
-		case <-ticker.C:
-			// change the square's color
-			s.Lock()
-			s.Color = utils.RandomBrightColor()
-			s.Unlock()
-		case <-s.ShotCh:
-			// if the square makes a shot
-			// he loses his invulnerability
-			s.restoreVulnerability(nativeColor)
-			return
-		}
+// ApplyStats restores the Square's health, armor,
+// speed, size and ammo from its base stats. The weapon
+// of the stats is equipped if the square can't choose it
+// or has the weapon it can't choose anymore.
+func (s *Square) ApplyStats() {
+	s.Health = s.Stats.Health
+	s.Armor = s.Stats.Armor
+	s.outOfCombat.Stop()
+	s.regeneration.Stop()
+	s.Speed = s.Stats.Speed
+	s.Size = s.Stats.Size
+	s.ClearEffects()
+	s.stopDash()
+	if !s.Stats.Weapon.Selectable() || !s.WeaponKind.Selectable() {
+		s.Equip(s.Stats.Weapon)
 	}
+	s.RefillAmmo()
 }
 
-// restoreVulnerability sets the square's vulnerability
-// to true and the color to the native one.
-//
-// Accepts a native rgba color to set.
-func (s *Square) restoreVulnerability(c color.RGBA) {
-	s.Lock()
-	defer s.Unlock()
+// Eliminate removes the Square from the round till the next one:
+// it can't move and shoot and isn't hit anymore.
+func (s *Square) Eliminate() {
+	s.revive()
+	s.Eliminated = true
+	s.Health = 0
+	s.Armor = 0
+	s.ClearBullets()
+	s.ClearEffects()
+	s.stopDash()
+	s.cooldown.Stop()
+	s.stopReload()
+	s.CanShoot = false
+}
+
+// Restart prepares the square for a new round:
+// resets its stats, returns it to the spawn point
+// and removes its bullets.
+func (s *Square) Restart() {
+	s.revive()
+	s.Eliminated = false
+	s.Kills = 0
+	s.Deaths = 0
+	s.Combat = CombatStats{}
+	s.ApplyStats()
+	s.Position = s.Spawn
+	s.ClearBullets()
+
+	// the weapon is ready and the square is vulnerable
+	s.cooldown.Stop()
+	s.CanShoot = s.IsBot
+	if !s.Vulnerable {
+		s.restoreVulnerability()
+	}
+}
+
+// restoreVulnerability sets the square's vulnerability to true.
+func (s *Square) restoreVulnerability() {
 	s.Vulnerable = true
-	// set the native color to the square
-	s.Color = c
+	s.invulnerability.Stop()
 }
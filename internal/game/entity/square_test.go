@@ -0,0 +1,101 @@
+package entity
+
+import (
+	"image/color"
+	"math/rand"
+	"online_shooter/internal/config/configtest"
+	"online_shooter/internal/game/geometry"
+	"testing"
+	"time"
+)
+
+func TestMain(m *testing.M) {
+	configtest.Main(m)
+}
+
+var random = rand.New(rand.NewSource(1))
+
+func TestShootReloadsWeapon(t *testing.T) {
+	s := NewBot(1, random)
+	s.Shoot(geometry.Point{X: 100, Y: 100}, random)
+
+	if s.Bullets[0] == nil || s.CanShoot {
+		t.Fatal("square didn't shoot")
+	}
+
+	s.UpdateTimers(s.Weapon.FireRate - time.Millisecond)
+	if s.CanShoot {
+		t.Fatal("weapon is reloaded too early")
+	}
+
+	s.UpdateTimers(time.Millisecond)
+	if !s.CanShoot {
+		t.Fatal("weapon isn't reloaded")
+	}
+}
+
+func TestRespawnInvulnerability(t *testing.T) {
+	nativeColor := color.RGBA{R: 1, G: 2, B: 3, A: 255}
+	s := NewBot(1, random)
+	s.Color = nativeColor
+	s.Health = 10
+	shooter := NewBot(2, random)
+
+	if !s.GetDamage(&Bullet{Damage: 20}, shooter) {
+		t.Fatal("square isn't killed")
+	}
+	s.Respawn(s.Spawn)
+	if s.Vulnerable || s.Deaths != 1 || shooter.Kills != 1 {
+		t.Fatalf("got vulnerable %v deaths %d kills %d, want a killed invulnerable square",
+			s.Vulnerable, s.Deaths, shooter.Kills)
+	}
+
+	s.UpdateTimers(invulnerabilitySeconds*time.Second - time.Millisecond)
+	if s.Vulnerable || s.Color != nativeColor {
+		t.Fatalf("got vulnerable %v color %v, want the protected square to keep its color", s.Vulnerable, s.Color)
+	}
+
+	s.UpdateTimers(time.Millisecond)
+	if !s.Vulnerable || s.Color != nativeColor {
+		t.Fatalf("got vulnerable %v color %v, want vulnerable with the native color", s.Vulnerable, s.Color)
+	}
+}
+
+func TestShootEndsInvulnerability(t *testing.T) {
+	s := NewBot(1, random)
+	s.Respawn(s.Spawn)
+	s.UpdateTimers(time.Millisecond)
+
+	s.Shoot(geometry.Point{X: 100, Y: 100}, random)
+	if !s.Vulnerable {
+		t.Fatal("got an invulnerable square after the shot")
+	}
+
+	// the stopped invulnerability timer doesn't change the square anymore
+	s.UpdateTimers(invulnerabilitySeconds * time.Second)
+	if !s.Vulnerable {
+		t.Fatal("got an invulnerable square after the invulnerability ended")
+	}
+}
+
+func TestDeadSquareWaitsForRespawn(t *testing.T) {
+	s := NewBot(1, random)
+	killer := NewBot(2, random)
+	s.Die(killer, time.Second)
+	if !s.Dead || s.Killer != killer.Id || s.RespawnIn != time.Second {
+		t.Fatalf("got dead %v killed by %d respawning in %v, want a dead square", s.Dead, s.Killer, s.RespawnIn)
+	}
+
+	if s.UpdateRespawn(time.Second - time.Millisecond) {
+		t.Fatal("square respawned too early")
+	}
+	if !s.UpdateRespawn(time.Millisecond) {
+		t.Fatal("square didn't respawn after the delay")
+	}
+
+	spawn := geometry.Point{X: 10, Y: 20}
+	s.Respawn(spawn)
+	if s.Dead || s.Killer != 0 || s.Position != spawn || s.Health != s.Stats.Health {
+		t.Fatalf("got dead %v at %v with health %d, want an alive square at the spawn", s.Dead, s.Position, s.Health)
+	}
+}
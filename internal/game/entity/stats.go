@@ -0,0 +1,65 @@
+package entity
+
+import (
+	"online_shooter/internal/config"
+	"time"
+)
+
+// default rules of the damage
+const (
+	DefaultHitSlowdown       = 0.1
+	DefaultSlowdownRecovery  = 2 * time.Second
+	DefaultRegenerationDelay = 5 * time.Second
+)
+
+// Stats are the base stats of the square which it gets
+// back when it respawns. The game mode can give
+// the teams their own stats instead of the default ones.
+type Stats struct {
+	Health int32
+	// Armor absorbs the damage before the health
+	Armor int32
+	Speed float32
+	Size  float32
+	// Weapon is the weapon the square respawns with
+	// if it can't choose its own one
+	Weapon WeaponKind
+	// Damage are the rules of the damage the square takes
+	Damage DamageRules
+}
+
+// DamageRules are the rules of the damage pipeline
+// after the armor has absorbed its part.
+type DamageRules struct {
+	// HitSlowdown is a part of the speed every hit takes
+	// till the square recovers in SlowdownRecovery,
+	// zero means the hits don't slow the square down
+	HitSlowdown      float32
+	SlowdownRecovery time.Duration
+	// Regeneration is the health restored every second
+	// when the square hasn't been damaged for RegenerationDelay,
+	// zero means the health doesn't regenerate
+	Regeneration      int32
+	RegenerationDelay time.Duration
+}
+
+// DefaultStats returns the stats from the config.
+func DefaultStats() Stats {
+	return Stats{
+		Health: config.SquareHealth(),
+		Speed:  config.SquareSpeed(),
+		Size:   config.SquareSize(),
+		Weapon: Pistol,
+		Damage: DefaultDamageRules(),
+	}
+}
+
+// DefaultDamageRules returns the rules where the hits
+// slow the square down and the health doesn't regenerate.
+func DefaultDamageRules() DamageRules {
+	return DamageRules{
+		HitSlowdown:       DefaultHitSlowdown,
+		SlowdownRecovery:  DefaultSlowdownRecovery,
+		RegenerationDelay: DefaultRegenerationDelay,
+	}
+}
@@ -0,0 +1,127 @@
+package entity
+
+import (
+	"online_shooter/internal/config"
+	"time"
+)
+
+// WeaponKind is a kind of the square's weapon
+type WeaponKind int
+
+// kinds of the weapons, the players choose one of the first
+// PlayerWeapons kinds, the rest are given by the game modes
+const (
+	Pistol WeaponKind = iota
+	Shotgun
+	SMG
+	Sniper
+	Claws
+
+	weaponKinds = iota
+)
+
+// PlayerWeapons is an amount of the weapons the players can switch between
+const PlayerWeapons = 4
+
+// Weapon describes how the square shoots.
+type Weapon struct {
+	Name string
+	// FireRate is a time between the shots
+	FireRate time.Duration
+	// Spread is the max angle in radians
+	// the bullets deviate from the aim
+	Spread float32
+	// Pellets is an amount of the bullets in one shot
+	Pellets     int
+	BulletSpeed float32
+	Damage      int32
+	// Range is a distance the bullets fly,
+	// zero means they fly till they hit something
+	Range float32
+	// Burns shows if the bullets set the hit squares on fire
+	Burns bool
+	// Magazine is an amount of the shots before the reloading,
+	// zero means the weapon doesn't need ammo
+	Magazine int
+	// Reserve is the max amount of the spare rounds
+	Reserve    int
+	ReloadTime time.Duration
+}
+
+// NewWeapon creates the weapon of the kind
+// using the bullet and square parameters from the config.
+// The pistol is the default weapon of every square.
+//
+// Accepts the kind of the weapon.
+//
+// Returns the created weapon.
+func NewWeapon(kind WeaponKind) Weapon {
+	speed := config.BulletSpeed()
+	damage := config.BulletDamage()
+
+	switch kind {
+	case Shotgun:
+		return Weapon{
+			Name:        "SHOTGUN",
+			FireRate:    900 * time.Millisecond,
+			Spread:      0.25,
+			Pellets:     6,
+			BulletSpeed: speed * 0.9,
+			Damage:      damage / 2,
+			Range:       config.SquareSize() * 8,
+			Magazine:    6,
+			Reserve:     24,
+			ReloadTime:  2 * time.Second,
+		}
+	case SMG:
+		return Weapon{
+			Name:        "SMG",
+			FireRate:    120 * time.Millisecond,
+			Spread:      0.08,
+			Pellets:     1,
+			BulletSpeed: speed * 1.1,
+			Damage:      damage / 2,
+			Magazine:    30,
+			Reserve:     120,
+			ReloadTime:  1800 * time.Millisecond,
+		}
+	case Sniper:
+		return Weapon{
+			Name:        "SNIPER",
+			FireRate:    1500 * time.Millisecond,
+			Pellets:     1,
+			BulletSpeed: speed * 2,
+			Damage:      damage * 3,
+			Magazine:    5,
+			Reserve:     15,
+			ReloadTime:  2500 * time.Millisecond,
+		}
+	case Claws:
+		// the infected hit hard but only nearby and burn the victims
+		return Weapon{
+			Name:        "CLAWS",
+			FireRate:    500 * time.Millisecond,
+			Pellets:     1,
+			BulletSpeed: speed,
+			Damage:      damage * 2,
+			Range:       config.SquareSize() * 3,
+			Burns:       true,
+		}
+	default:
+		return Weapon{
+			Name:        "PISTOL",
+			FireRate:    500 * time.Millisecond,
+			Pellets:     1,
+			BulletSpeed: speed,
+			Damage:      damage,
+			Magazine:    12,
+			Reserve:     48,
+			ReloadTime:  time.Second,
+		}
+	}
+}
+
+// Selectable checks if the players can switch to the weapon kind.
+func (k WeaponKind) Selectable() bool {
+	return k >= 0 && k < PlayerWeapons
+}
@@ -0,0 +1,108 @@
+package entity
+
+import (
+	"online_shooter/internal/game/geometry"
+	"testing"
+	"time"
+)
+
+func TestShotgunFiresPellets(t *testing.T) {
+	s := NewBot(1, random)
+	s.Equip(Shotgun)
+	s.Shoot(geometry.Point{X: 100, Y: 0}, random)
+
+	pellets := 0
+	for _, b := range s.Bullets {
+		if b == nil {
+			continue
+		}
+		pellets++
+		if b.Vector.X <= 0 || b.Range != s.Weapon.Range {
+			t.Fatalf("got bullet vector %v range %v, want a pellet towards the aim", b.Vector, b.Range)
+		}
+	}
+	if pellets != s.Weapon.Pellets || s.Ammo.Magazine != s.Weapon.Magazine-1 {
+		t.Fatalf("got %d pellets and %d rounds left, want one shot of %d pellets",
+			pellets, s.Ammo.Magazine, s.Weapon.Pellets)
+	}
+}
+
+func TestSwitchWeaponKeepsBullets(t *testing.T) {
+	s := NewBot(1, random)
+	s.Shoot(geometry.Point{X: 100, Y: 0}, random)
+	s.UpdateTimers(s.Weapon.FireRate)
+
+	s.SwitchWeapon(Sniper)
+	if s.WeaponKind != Sniper || s.Bullets[0] == nil || s.CanShoot {
+		t.Fatalf("got weapon %s with bullet %v and can shoot %v, want the sniper reloading",
+			s.Weapon.Name, s.Bullets[0], s.CanShoot)
+	}
+
+	// the fire rate of the new weapon has to pass before the shot
+	s.UpdateTimers(s.Weapon.FireRate - time.Millisecond)
+	if s.CanShoot {
+		t.Fatal("weapon is ready too early")
+	}
+	s.UpdateTimers(time.Millisecond)
+	if !s.CanShoot {
+		t.Fatal("weapon isn't ready")
+	}
+}
+
+func TestModeWeaponCantBeSwitched(t *testing.T) {
+	s := NewBot(1, random)
+	s.Stats.Weapon = Claws
+	s.ApplyStats()
+
+	s.SwitchWeapon(Shotgun)
+	if s.WeaponKind != Claws {
+		t.Fatalf("got weapon %s, want the claws", s.Weapon.Name)
+	}
+
+	// the square gets its own weapon back with the default stats
+	s.Stats = DefaultStats()
+	s.ApplyStats()
+	if s.WeaponKind != Pistol {
+		t.Fatalf("got weapon %s, want the pistol", s.Weapon.Name)
+	}
+}
+
+func TestReloadFillsMagazine(t *testing.T) {
+	s := NewBot(1, random)
+	s.Ammo.Magazine = 0
+
+	// the empty magazine is reloaded instead of the shot
+	s.Shoot(geometry.Point{X: 100, Y: 0}, random)
+	if !s.Reloading() || s.Bullets != nil {
+		t.Fatal("square didn't start reloading")
+	}
+
+	s.UpdateTimers(s.Weapon.ReloadTime / 2)
+	if s.ReloadProgress < 0.49 || s.ReloadProgress > 0.51 {
+		t.Fatalf("got reload progress %v, want a half", s.ReloadProgress)
+	}
+
+	s.UpdateTimers(s.Weapon.ReloadTime / 2)
+	want := Ammo{Magazine: s.Weapon.Magazine, Reserve: s.Weapon.Reserve - s.Weapon.Magazine}
+	if s.Reloading() || s.Ammo != want {
+		t.Fatalf("got ammo %+v, want %+v", s.Ammo, want)
+	}
+}
+
+func TestShotInterruptsReload(t *testing.T) {
+	s := NewBot(1, random)
+	s.Ammo.Magazine = 1
+	s.Reload()
+
+	s.Shoot(geometry.Point{X: 100, Y: 0}, random)
+	if s.Reloading() || s.Ammo.Magazine != 0 || s.Ammo.Reserve != s.Weapon.Reserve {
+		t.Fatalf("got reloading %v with ammo %+v, want the interrupted reload", s.Reloading(), s.Ammo)
+	}
+
+	// the reserve is refilled but the magazine is kept
+	s.Ammo.Reserve = 0
+	s.RefillReserve()
+	if s.Ammo.Magazine != 0 || s.Ammo.Reserve != s.Weapon.Reserve {
+		t.Fatalf("got ammo %+v, want the full reserve", s.Ammo)
+	}
+}
@@ -0,0 +1,97 @@
+package entity
+
+import (
+	"online_shooter/internal/game/geometry"
+	"time"
+)
+
+// zonePointInterval is a time the zone must be held
+// uncontested to give a point to the holding team
+const zonePointInterval = time.Second
+
+// Zone is a control zone in the king-of-the-hill mode.
+// The team whose squares are alone in the zone holds it
+// and gets a point for every second of holding. The zone is
+// contested while squares of several teams are inside it.
+type Zone struct {
+	Position geometry.Point `json:"position"`
+	Size     float32        `json:"size"`
+	// Holder is the team holding the zone
+	// or zero if nobody holds it
+	Holder    int  `json:"holder,omitempty"`
+	Contested bool `json:"contested,omitempty"`
+	// Progress is a part of the time
+	// till the next point from zero to one
+	Progress float32 `json:"progress"`
+
+	// time the zone has been held since the last point
+	held time.Duration
+}
+
+// NewZone creates a free zone.
+//
+// Accepts a position of the zone and its size.
+//
+// Returns a pointer to the created zone.
+func NewZone(position geometry.Point, size float32) *Zone {
+	return &Zone{Position: position, Size: size}
+}
+
+// Hold updates the holder of the zone by the squares inside it
+// and counts the time the holder has held it. The contested zone
+// is kept by its holder but doesn't give points till only
+// one team is inside it.
+//
+// Accepts the team inside the zone or zero if the zone is empty,
+// a flag showing if several teams are inside it and the elapsed game time.
+//
+// Returns true if the holder has earned a point.
+func (z *Zone) Hold(team int, contested bool, dt time.Duration) bool {
+	z.Contested = contested
+	if contested {
+		return false
+	}
+
+	// the holding time starts again when the holder changes
+	if team != z.Holder {
+		z.Holder = team
+		z.held = 0
+		z.Progress = 0
+	}
+	if team == 0 {
+		return false
+	}
+
+	z.held += dt
+	scored := z.held >= zonePointInterval
+	if scored {
+		z.held -= zonePointInterval
+	}
+	z.Progress = float32(z.held) / float32(zonePointInterval)
+
+	return scored
+}
+
+// Move moves the zone to the new position and frees it.
+//
+// Accepts the new position of the zone.
+func (z *Zone) Move(position geometry.Point) {
+	z.Position = position
+	z.Holder = 0
+	z.Contested = false
+	z.Progress = 0
+	z.held = 0
+}
+
+// Center returns the center of the zone.
+func (z *Zone) Center() geometry.Point {
+	return geometry.Point{X: z.Position.X + z.Size/2, Y: z.Position.Y + z.Size/2}
+}
+
+// Clone creates a copy of the zone.
+//
+// Returns a pointer to the copy.
+func (z *Zone) Clone() *Zone {
+	c := *z
+	return &c
+}
@@ -2,31 +2,62 @@ package game
 
 import (
 	"github.com/chewxy/math32"
-	"math/rand"
 	"online_shooter/internal/game/entity"
 	"online_shooter/internal/game/geometry"
 )
 
 const (
 	botBlindZoneLevel = 8
+	// botFightZoneLevel is a distance in the bot's sizes
+	// to the enemy the bot fights instead of going to the objective
+	botFightZoneLevel = 4
+	// the bot reloads in the fight when less than
+	// 1/botLowAmmoLevel of the magazine is left
+	botLowAmmoLevel = 4
+	// botDodgeZoneLevel is a distance in the bot's sizes
+	// to the bullet the bot dodges by the dash
+	botDodgeZoneLevel = 4
 )
 
 // CountMovingVector counts a vector which the bot moves with.
+// The bot goes to the pickup it needs or to the objective
+// of the game mode unless it fights an enemy nearby.
 //
 // Accepts a pointer to the bot, a pointer to the enemy which is an aim
 // and a distance to the enemy.
 //
 // Returns a bot's moving vector.
 func (g *Game) CountMovingVector(bot *entity.Square, enemy *entity.Square, distance float32) geometry.Vector {
-	bot.RLock()
-	defer bot.RUnlock()
+	// go to the pickup unless the bot fights a weaker enemy
+	target, ok := g.pickupTarget(bot)
+	if ok && (enemy == nil || distance > bot.Size*botFightZoneLevel || bot.Health < enemy.Health) {
+		vector := &geometry.Vector{
+			X: target.X - bot.Position.X,
+			Y: target.Y - bot.Position.Y,
+		}
+		vector.Normalize()
+
+		return *vector
+	}
+
+	// go to the objective if there is no enemy nearby
+	objective, ok := g.mode.objective(g, bot)
+	if ok && (enemy == nil || distance > bot.Size*botFightZoneLevel) {
+		vector := &geometry.Vector{
+			X: objective.X - bot.Position.X,
+			Y: objective.Y - bot.Position.Y,
+		}
+		vector.Normalize()
+
+		return *vector
+	}
 
 	// if there are no enemies, or they are too far away
 	if enemy == nil || distance > bot.Size*botBlindZoneLevel {
 		// move towards a random vector
 		vector := &geometry.Vector{
-			X: rand.Float32() - 0.5*4 + g.Arena.Width/2 - bot.Position.X,
-			Y: rand.Float32() - 0.5*4 + g.Arena.Height/2 - bot.Position.Y,
+			X: g.random.Float32() - 0.5*4 + g.Arena.Width/2 - bot.Position.X,
+			Y: g.random.Float32() - 0.5*4 + g.Arena.Height/2 - bot.Position.Y,
 		}
 
 		// normalize vector
@@ -35,9 +66,6 @@ func (g *Game) CountMovingVector(bot *entity.Square, enemy *entity.Square, dista
 		return *vector
 	}
 
-	enemy.RLock()
-	defer enemy.RUnlock()
-
 	// count the vector to the enemy
 	vector := &geometry.Vector{
 		X: enemy.Position.X - bot.Position.X,
@@ -48,7 +76,8 @@ func (g *Game) CountMovingVector(bot *entity.Square, enemy *entity.Square, dista
 	vector.Normalize()
 
 	// if the enemy's health is more than the bot's health
-	if bot.Health < enemy.Health {
+	// or the bot reloads its weapon
+	if bot.Health < enemy.Health || bot.Reloading() {
 		// bot moves in the opposite direction from the enemy
 		vector.X *= -1
 		vector.Y *= -1
@@ -61,27 +90,34 @@ func (g *Game) CountMovingVector(bot *entity.Square, enemy *entity.Square, dista
 	return *vector
 }
 
-// FindEnemy finds the nearest enemy.
+// FindEnemy finds the nearest enemy inside the bot's
+// blind zone as the bot doesn't react to the enemies
+// which are further.
 //
 // Accepts a pointer to the bot that finds enemies.
 //
 // Returns a pointer to the enemy square and the distance to it.
 func (g *Game) FindEnemy(bot *entity.Square) (*entity.Square, float32) {
-	bot.RLock()
-	defer bot.RUnlock()
-
 	// create variables
 	minDistance := math32.Hypot(g.Arena.Width, g.Arena.Height)
 	var nearestEnemy *entity.Square
 
-	for _, enemy := range g.Squares {
+	// go through the squares inside the blind zone
+	blindZone := bot.Size * botBlindZoneLevel
+	area := geometry.Rect{
+		X:      bot.Position.X - blindZone,
+		Y:      bot.Position.Y - blindZone,
+		Width:  2*blindZone + bot.Size,
+		Height: 2*blindZone + bot.Size,
+	}
+	g.grids.foundSquares = g.grids.squares.Query(area, g.grids.foundSquares[:0])
+	for _, enemy := range g.grids.foundSquares {
 		// check if a potential enemy is not the finder itself
-		if enemy == bot {
+		// or its teammate
+		if enemy == bot || bot.IsTeammate(enemy) {
 			continue
 		}
 
-		enemy.RLock()
-
 		// get the distance
 		distance := geometry.GetDistanceBetweenTwoPoints(bot.Position, enemy.Position)
 
@@ -91,8 +127,6 @@ func (g *Game) FindEnemy(bot *entity.Square) (*entity.Square, float32) {
 			minDistance = distance
 			nearestEnemy = enemy
 		}
-
-		enemy.RUnlock()
 	}
 
 	return nearestEnemy, minDistance
@@ -135,9 +169,6 @@ func (g *Game) CountShootingPoint(enemy *entity.Square, distance float32) *geome
 		return nil
 	}
 
-	enemy.RLock()
-	defer enemy.RUnlock()
-
 	// check the bot's blind zone for the shooting
 	if distance > enemy.Size*botBlindZoneLevel {
 		return nil
@@ -152,16 +183,103 @@ func (g *Game) CountShootingPoint(enemy *entity.Square, distance float32) *geome
 	return enemyCenter
 }
 
+// ReloadBot reloads the bot's weapon when it is safe:
+// there are no enemies nearby, or the magazine is nearly empty
+// and the enemy is too far to fight. The bot switches
+// to another weapon when the ammo is over.
+//
+// Accepts a pointer to the bot, a pointer to the nearest enemy
+// and a distance to it.
+func (g *Game) ReloadBot(bot *entity.Square, enemy *entity.Square, distance float32) {
+	if bot.OutOfAmmo() {
+		if kind, ok := bot.LoadedWeapon(); ok {
+			bot.SwitchWeapon(kind)
+		}
+		return
+	}
+
+	if !bot.NeedsReload() {
+		return
+	}
+
+	lowAmmo := bot.Ammo.Magazine*botLowAmmoLevel <= bot.Weapon.Magazine
+	if enemy == nil || distance > bot.Size*botBlindZoneLevel ||
+		lowAmmo && distance > bot.Size*botFightZoneLevel {
+		bot.Reload()
+	}
+}
+
+// dodgeVector finds an enemy bullet flying towards the bot
+// and counts the direction of the dash out of its way.
+// The bot doesn't dodge while it can't dash.
+//
+// Accepts a pointer to the bot.
+//
+// Returns the vector of the dash and false if there is nothing to dodge.
+func (g *Game) dodgeVector(bot *entity.Square) (geometry.Vector, bool) {
+	if !bot.DashReady() {
+		return geometry.Vector{}, false
+	}
+
+	// go through the bullets inside the dodge zone
+	dodgeZone := bot.Size * botDodgeZoneLevel
+	area := geometry.Rect{
+		X:      bot.Position.X - dodgeZone,
+		Y:      bot.Position.Y - dodgeZone,
+		Width:  2*dodgeZone + bot.Size,
+		Height: 2*dodgeZone + bot.Size,
+	}
+	center := geometry.Point{X: bot.Position.X + bot.Size/2, Y: bot.Position.Y + bot.Size/2}
+	g.grids.foundSquares = g.grids.bullets.Query(area, g.grids.foundSquares[:0])
+	for _, shooter := range g.grids.foundSquares {
+		// the bullets of the teammates fly through the bot without the friendly fire
+		if shooter == bot || !g.friendlyFire && bot.IsTeammate(shooter) {
+			continue
+		}
+
+		for _, b := range shooter.Bullets {
+			if b == nil {
+				continue
+			}
+
+			// the way from the bullet to the bot along
+			// the bullet's flight and across it
+			toBot := geometry.Vector{
+				X: center.X - b.Position.X - b.Size/2,
+				Y: center.Y - b.Position.Y - b.Size/2,
+			}
+			along := toBot.X*b.Vector.X + toBot.Y*b.Vector.Y
+			across := toBot.X*b.Vector.Y - toBot.Y*b.Vector.X
+			if along <= 0 || along > dodgeZone || math32.Abs(across) > (bot.Size+b.Size)/2 {
+				continue
+			}
+
+			// dash across the flight away from the bullet's line
+			dodge := geometry.Vector{X: b.Vector.Y, Y: -b.Vector.X}
+			if across < 0 {
+				dodge.X, dodge.Y = -dodge.X, -dodge.Y
+			}
+
+			return dodge, true
+		}
+	}
+
+	return geometry.Vector{}, false
+}
+
 // FindWeakestBot finds the weakest bot in the game.
 //
+// Accepts the team of the bot which is zero if
+// the bot can be from any team.
+//
 // Returns a pointer to the weakest bot if it exists
 // otherwise returns nil.
-func (g *Game) FindWeakestBot() *entity.Square {
+func (g *Game) FindWeakestBot(team int) *entity.Square {
 	var minRecord int16 = 32767
 	var weakestBot *entity.Square
 
-	for _, s := range g.Squares {
-		if s.IsBot {
+	for _, s := range g.SortedSquares() {
+		if s.IsBot && (team == 0 || s.Team == team) {
 			botRecord := int16(s.Kills - s.Deaths)
 			if minRecord > botRecord {
 				minRecord = botRecord
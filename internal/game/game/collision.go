@@ -12,28 +12,21 @@ import (
 //
 // Accepts Square pointer as an argument.
 func (g *Game) CheckSquareCollision(p *entity.Square) {
-	p.Lock()
-	defer p.Unlock()
-
 	// check if it is a collision with the obstacles
 	collision, obstacle := g.checkCollisionWithObstacles(p.Position, p.Size)
 	if collision {
 		// change square's position
 		// to not go through the obstacle
-		obstacle.RLock()
 		squareCollisionWithObstacle(p, obstacle)
-		obstacle.RUnlock()
 	}
 
 	// check if it is a collision with other squares
 	var square *entity.Square
-	collision, square = g.checkCollisionWithSquares(p.Position, p.Size, p)
+	collision, square = g.checkCollisionWithSquares(p.Position, p.Size, p, false)
 	if collision {
 		// change square's position
 		// to not go through the player
-		square.RLock()
 		squareCollisionWithSquare(p, square)
-		square.RUnlock()
 	}
 
 	// check if it is a collision with the boards
@@ -47,18 +40,11 @@ func (g *Game) CheckSquareCollision(p *entity.Square) {
 //
 // Accepts a pointer to the player that shot the bullets.
 func (g *Game) CheckBulletsCollision(p *entity.Square) {
-	p.RLock()
-	defer p.RUnlock()
-
 	for i, b := range p.Bullets {
 		if b != nil {
-			b.Lock()
-
 			// check if it is a collision with obstacles
 			collision, obstacle := g.checkCollisionWithObstacles(b.Position, b.Size)
 			if collision {
-				obstacle.Lock()
-
 				// process the consequences of the obstacle and bullet collision
 				obstacle.GetDamage(b)
 
@@ -69,25 +55,22 @@ func (g *Game) CheckBulletsCollision(p *entity.Square) {
 				} else {
 					// otherwise remove the bullet from the Arena
 					p.RemoveBullet(i)
-					obstacle.Unlock()
-					b.Unlock()
 					continue
 				}
-				obstacle.Unlock()
 			}
 
-			// check if it is a collision with players
+			// check if it is a collision with players,
+			// the bullets fly through the teammates without the friendly fire
 			var damagedPlayer *entity.Square
-			collision, damagedPlayer = g.checkCollisionWithSquares(b.Position, b.Size, p)
+			collision, damagedPlayer = g.checkCollisionWithSquares(b.Position, b.Size, p, !g.friendlyFire)
 			if collision {
 				// process the consequences of the square and bullet collision
-				damagedPlayer.Lock()
-				damagedPlayer.GetDamage(b, p)
-				damagedPlayer.Unlock()
+				if damagedPlayer.GetDamage(b, p) {
+					g.killSquare(p, damagedPlayer)
+				}
 
 				// remove the bullet from the arena
 				p.RemoveBullet(i)
-				b.Unlock()
 				continue
 			}
 
@@ -96,32 +79,25 @@ func (g *Game) CheckBulletsCollision(p *entity.Square) {
 			if collision {
 				// remove the bullet from the arena
 				p.RemoveBullet(i)
-				b.Unlock()
 				continue
 			}
-
-			b.Unlock()
 		}
 	}
 }
 
 // checkCollisionWithObstacles checks if it is a collision between
-// an object and the obstacles.
+// an object and the obstacles near the object.
 //
 // Accepts the object's position and a size of the object.
 //
 // Returns true and a pointer to the obstacle which has a collision with the object.
 // if there is no collision - method returns false and nil.
 func (g *Game) checkCollisionWithObstacles(objectPosition geometry.Point, objectSize float32) (bool, *arena.Obstacle) {
-	// go through every obstacle
-	g.Arena.ArenaMutex.Lock()
-	defer g.Arena.ArenaMutex.Unlock()
-	for _, o := range g.Arena.Obstacles {
-		o.Lock()
-
+	// go through every obstacle near the object
+	g.grids.foundObstacles = g.QueryObstacles(objectArea(objectPosition, objectSize), g.grids.foundObstacles[:0])
+	for _, o := range g.grids.foundObstacles {
 		// skip if the obstacle is invulnerable
 		if !o.Vulnerable {
-			o.Unlock()
 			continue
 		}
 
@@ -129,11 +105,8 @@ func (g *Game) checkCollisionWithObstacles(objectPosition geometry.Point, object
 		// and the obstacle
 		if isCollision(objectPosition, o.Position, objectSize, o.Size) {
 			// if there is a collision
-			o.Unlock()
 			return true, o
 		}
-
-		o.Unlock()
 	}
 
 	// if there is no collision
@@ -141,27 +114,31 @@ func (g *Game) checkCollisionWithObstacles(objectPosition geometry.Point, object
 }
 
 // checkCollisionWithSquares checks if it is a collision between
-// an object and the squares.
+// an object and the squares near the object.
 //
-// Accepts the object's position, a size of the object
-// and a pointer to the square that checks a collision.
+// Accepts the object's position, a size of the object,
+// a pointer to the square that checks a collision
+// and a flag showing if the square's teammates are skipped.
 //
 // Returns true and a pointer to the square which has a collision with the object.
 // if there is no collision - method returns false and nil.
-func (g *Game) checkCollisionWithSquares(objectPosition geometry.Point, objectSize float32, shooter *entity.Square) (bool, *entity.Square) {
-	// go through every square
-	for _, s := range g.Squares {
+func (g *Game) checkCollisionWithSquares(objectPosition geometry.Point, objectSize float32, shooter *entity.Square, skipTeammates bool) (bool, *entity.Square) {
+	// go through every square near the object
+	g.grids.foundSquares = g.grids.squares.Query(objectArea(objectPosition, objectSize), g.grids.foundSquares[:0])
+	for _, s := range g.grids.foundSquares {
 		// square to check collision must be different from the square that asks for check
 		if shooter == s {
 			continue
 		}
 
-		s.RLock()
+		// skip the teammates if it is required
+		if skipTeammates && shooter.IsTeammate(s) {
+			continue
+		}
 
-		// if the square to check collision is invulnerable
-		// skip the check
-		if !s.Vulnerable {
-			s.RUnlock()
+		// if the square to check collision is invulnerable, dodges the bullets
+		// by the dash or has been killed during this tick skip the check
+		if !s.Vulnerable || g.dashInvulnerable && s.Dashing() || s.Dead || s.Eliminated {
 			continue
 		}
 
@@ -169,11 +146,8 @@ func (g *Game) checkCollisionWithSquares(objectPosition geometry.Point, objectSi
 		// and the square
 		if isCollision(objectPosition, s.Position, objectSize, s.Size) {
 			// if there is a collision
-			s.RUnlock()
 			return true, s
 		}
-
-		s.RUnlock()
 	}
 
 	// if there is no collision
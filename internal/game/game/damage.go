@@ -0,0 +1,80 @@
+package game
+
+import (
+	"online_shooter/internal/config"
+	"online_shooter/internal/game/entity"
+	"time"
+)
+
+const (
+	// royaleArmorFactor is a part of the health the squares
+	// of the battle royale get as the armor
+	royaleArmorFactor = 0.5
+	// objectiveRegeneration is the health restored every second
+	// out of combat in the modes with the objectives
+	objectiveRegeneration = 5
+	// survivalRegeneration is the health restored every second
+	// out of combat in the survival, so the survivors recover between the waves
+	survivalRegeneration = 10
+)
+
+// initStats counts the default stats of the squares in the game.
+// The mode gives its own damage rules and the rules set
+// in the settings replace the mode's ones.
+//
+// Accepts a pointer to the server settings instance.
+func (g *Game) initStats(settings *ServerSettings) {
+	g.stats = entity.DefaultStats()
+
+	// the rules of the mode
+	switch g.modeName {
+	case ModeCaptureTheFlag, ModeKingOfTheHill:
+		g.stats.Damage.Regeneration = objectiveRegeneration
+	case ModeBattleRoyale:
+		g.stats.Armor = int32(float32(config.SquareHealth()) * royaleArmorFactor)
+	case ModeSurvival:
+		g.stats.Damage.Regeneration = survivalRegeneration
+	}
+
+	// the rules of the server
+	g.stats.Armor = overrideSetting(g.stats.Armor, settings.Armor)
+	g.stats.Damage.HitSlowdown = overrideSetting(g.stats.Damage.HitSlowdown, settings.HitSlowdown)
+	g.stats.Damage.SlowdownRecovery = overrideSetting(g.stats.Damage.SlowdownRecovery, settings.SlowdownRecovery)
+	g.stats.Damage.Regeneration = overrideSetting(g.stats.Damage.Regeneration, settings.Regeneration)
+	g.stats.Damage.RegenerationDelay = overrideSetting(g.stats.Damage.RegenerationDelay, settings.RegenerationDelay)
+}
+
+// Stats returns the default stats of the squares in the game.
+func (g *Game) Stats() entity.Stats {
+	return g.stats
+}
+
+// TeamStats returns the stats of the team's squares.
+//
+// Accepts the team which is zero if the squares play on their own.
+//
+// Returns the team's stats or the default ones
+// if the team's stats don't differ from them.
+func (g *Game) TeamStats(team int) entity.Stats {
+	if stats, ok := g.teamStats[team]; ok {
+		return stats
+	}
+	return g.stats
+}
+
+// overrideSetting chooses the value of the setting.
+//
+// Accepts the mode's value and the server's value
+// which is zero if it isn't set and negative if it is off.
+//
+// Returns the value of the setting.
+func overrideSetting[T int32 | float32 | time.Duration](value, setting T) T {
+	switch {
+	case setting < 0:
+		return 0
+	case setting > 0:
+		return setting
+	default:
+		return value
+	}
+}
@@ -0,0 +1,185 @@
+package game
+
+import (
+	"online_shooter/internal/config"
+	"online_shooter/internal/game/entity"
+	"online_shooter/internal/game/geometry"
+	"online_shooter/internal/model"
+	"time"
+)
+
+// baseInsetFactor is a distance from the arena's
+// border to the center of the base zone in the square sizes
+const baseInsetFactor = 2
+
+// captureTheFlag is the mode where every team has a flag
+// in its base zone. A square takes the enemy's flag by touching it
+// and captures it by bringing it to its own base while its own
+// flag is at the base. The team with the most captures wins the round.
+type captureTheFlag struct {
+	scores []int
+}
+
+// newCaptureTheFlag creates the flags of the teams placing
+// the base zones on the teams' sides of the arena.
+//
+// Accepts a pointer to the game.
+//
+// Returns a pointer to the created mode.
+func newCaptureTheFlag(g *Game) *captureTheFlag {
+	size := config.SquareSize()
+	inset := size * baseInsetFactor
+	centers := []geometry.Point{
+		{X: inset, Y: g.Arena.Height / 2},
+		{X: g.Arena.Width - inset, Y: g.Arena.Height / 2},
+		{X: g.Arena.Width / 2, Y: inset},
+		{X: g.Arena.Width / 2, Y: g.Arena.Height - inset},
+	}
+
+	g.Flags = make([]*entity.Flag, g.teams)
+	for i := range g.Flags {
+		g.Flags[i] = entity.NewFlag(i+1, centers[i], size)
+	}
+
+	return &captureTheFlag{scores: make([]int, g.teams)}
+}
+
+func (m *captureTheFlag) start(g *Game) {
+	m.scores = make([]int, g.teams)
+	for _, flag := range g.Flags {
+		flag.Return()
+	}
+}
+
+// update moves the carried flags with their carriers,
+// lets the squares take and return the flags they touch
+// and counts the captures.
+func (m *captureTheFlag) update(g *Game, dt time.Duration) {
+	for _, flag := range g.Flags {
+		flag.UpdateTimers(dt)
+
+		// the carried flag moves with its carrier
+		// and is dropped if the carrier has left the game
+		if flag.Carrier != 0 {
+			if carrier := g.Squares[flag.Carrier]; carrier != nil {
+				flag.Follow(carrier)
+			} else {
+				flag.Drop()
+			}
+			continue
+		}
+
+		// find the square touching the flag
+		for _, square := range g.SortedSquares() {
+			if square.Dead || !isCollision(square.Position, flag.Position, square.Size, flag.Size) {
+				continue
+			}
+
+			// the teammate returns the dropped flag
+			if square.Team == flag.Team {
+				if !flag.AtBase {
+					flag.Return()
+					break
+				}
+				continue
+			}
+
+			// the enemy takes the flag if it doesn't carry another one
+			if carriedFlag(g, square) == nil {
+				flag.Take(square)
+				break
+			}
+		}
+	}
+
+	// count the captures
+	for _, flag := range g.Flags {
+		if flag.Carrier == 0 {
+			continue
+		}
+
+		carrier := g.Squares[flag.Carrier]
+		own := g.Flags[carrier.Team-1]
+		if own.AtBase && own.Base.Intersects(carrier.Position, carrier.Size) {
+			m.scores[carrier.Team-1]++
+			flag.Return()
+		}
+	}
+}
+
+// kill drops the flag carried by the victim where it was killed.
+func (m *captureTheFlag) kill(g *Game, _, victim *entity.Square) {
+	if flag := carriedFlag(g, victim); flag != nil {
+		flag.Drop()
+	}
+}
+
+func (m *captureTheFlag) finished(g *Game) bool {
+	return g.teamReachedScoreLimit(m.scores)
+}
+
+// leader finds the team with the most captures.
+func (m *captureTheFlag) leader(*Game) winner {
+	return winner{team: bestTeam(m.scores)}
+}
+
+func (m *captureTheFlag) teamScores() []int {
+	return m.scores
+}
+
+func (m *captureTheFlag) fillStatus(*model.MatchStatus) {}
+
+// objective sends the bot with the enemy's flag to its base,
+// otherwise the bot returns its own flag or chases its carrier
+// and goes for the nearest enemy's flag if its own flag is safe.
+func (m *captureTheFlag) objective(g *Game, bot *entity.Square) (geometry.Point, bool) {
+	own := g.Flags[bot.Team-1]
+
+	// bring the flag home
+	if carriedFlag(g, bot) != nil {
+		return own.Base.Center(), true
+	}
+
+	// chase the carrier of the own flag
+	if carrier := g.Squares[own.Carrier]; carrier != nil {
+		return carrier.Position, true
+	}
+
+	// return the dropped own flag
+	if !own.AtBase {
+		return own.Position, true
+	}
+
+	// go for the nearest enemy's flag
+	var target *entity.Flag
+	var minDistance float32
+	for _, flag := range g.Flags {
+		if flag.Team == bot.Team || flag.Carrier != 0 {
+			continue
+		}
+		distance := geometry.GetDistanceBetweenTwoPoints(bot.Position, flag.Position)
+		if target == nil || distance < minDistance {
+			target = flag
+			minDistance = distance
+		}
+	}
+	if target == nil {
+		return geometry.Point{}, false
+	}
+
+	return target.Position, true
+}
+
+// carriedFlag finds the flag carried by the square.
+//
+// Accepts a pointer to the game and a pointer to the square.
+//
+// Returns a pointer to the flag or nil if the square carries no flag.
+func carriedFlag(g *Game, square *entity.Square) *entity.Flag {
+	for _, flag := range g.Flags {
+		if flag.Carrier == square.Id {
+			return flag
+		}
+	}
+	return nil
+}
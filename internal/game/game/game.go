@@ -5,8 +5,11 @@ import (
 	"online_shooter/internal/game/arena"
 	"online_shooter/internal/game/camera"
 	"online_shooter/internal/game/entity"
-	"online_shooter/internal/menu"
+	"online_shooter/internal/model"
+	"slices"
+	"sort"
 	"sync"
+	"time"
 )
 
 type Game struct {
@@ -17,18 +20,99 @@ type Game struct {
 	Camera    *camera.Camera
 	Squares   map[int64]*entity.Square
 	Player    *entity.Square
+	// Scoreboard stores the stats of every square
+	// received by the client from the server
+	Scoreboard []model.PlayerStatus
+	// Match is the state of the match
+	// received by the client from the server
+	Match *model.MatchStatus
+	// Flags are the teams' flags in the capture-the-flag mode
+	Flags []*entity.Flag
+	// Zones are the control zones in the king-of-the-hill mode
+	Zones []*entity.Zone
+	// SafeZone is the shrinking safe zone in the battle royale
+	SafeZone *entity.SafeZone
+	// Spectating is an id of the square the eliminated
+	// player watches received by the client from the server
+	Spectating int64
+	// KillFeed stores the latest kills
+	// received by the client from the server
+	KillFeed []KillFeedEntry
+	// Seed is a seed of the game's random source
+	Seed   int64
+	random *rand.Rand
+	grids  grids
+	match  match
+
+	// rules of the game mode
+	mode         mode
+	modeName     string
+	teams        int
+	friendlyFire bool
+	// dashInvulnerable makes the dashing squares invulnerable
+	dashInvulnerable bool
+	// stats are the default stats of the squares and teamStats
+	// are the stats of the teams which differ from the default ones
+	stats     entity.Stats
+	teamStats map[int]entity.Stats
+	// respawnDelay is a time the killed squares wait before they respawn
+	respawnDelay time.Duration
+	// killEvents are the kills of the last step
+	killEvents []model.KillEvent
+
+	// buffers for the squares and the obstacles sorted by id
+	sortedSquares   []*entity.Square
+	sortedObstacles []*arena.Obstacle
 }
 
 // InitServerGame inits the game with settings parameters.
 // Method inits the game arena and generates the required amount of Squares.
 //
 // Accepts a pointer to the server settings instance.
-func (g *Game) InitServerGame(settings *menu.ServerSettings) {
+func (g *Game) InitServerGame(settings *ServerSettings) {
+	// generate a seed if it is not set, zero is never
+	// used as a seed so every game can be reproduced
+	g.Seed = settings.Seed
+	for g.Seed == 0 {
+		g.Seed = time.Now().UnixNano()
+	}
+
+	// init the random source, all the random values
+	// in the game are taken from it to make the game reproducible
+	g.random = rand.New(rand.NewSource(g.Seed))
+
+	g.ricochet = settings.Ricochet
+
+	// init the rules of the game mode
+	g.modeName = settings.Mode
+	if !slices.Contains(Modes, g.modeName) {
+		g.modeName = ModeDeathmatch
+	}
+	g.teams = parseTeams(settings)
+	g.friendlyFire = settings.FriendlyFire
+	g.dashInvulnerable = settings.DashInvulnerable
+	g.initStats(settings)
+	g.initRespawn(settings)
+
 	// init the arena
-	g.Arena = arena.NewArena(settings.PlayerCount, settings.ObstacleLevel)
+	g.Arena = arena.NewArena(settings.PlayerCount, settings.ObstacleLevel, g.teams, g.random)
+
+	// generate squares, the bots of the survival come in waves
+	if g.modeName == ModeSurvival {
+		g.generateSquares(0)
+	} else {
+		g.generateSquares(settings.PlayerCount)
+	}
 
-	// generate squares
-	g.generateSquares(settings.PlayerCount)
+	// init the grids for the collision queries
+	g.initGrids()
+
+	// start the match before the game mode
+	// which may depend on the match's limits
+	g.initMatch(settings)
+
+	// init the state of the game mode
+	g.mode = newMode(g, settings)
 
 	// set the flag that game is active
 	g.Active = true
@@ -38,6 +122,9 @@ func (g *Game) InitServerGame(settings *menu.ServerSettings) {
 // setting the game to active state and
 // initializing camera with correct position.
 func (g *Game) InitClientGame() {
+	// init the map, squares are added by the server updates
+	g.Squares = make(map[int64]*entity.Square)
+
 	// init camera
 	g.Camera = camera.NewCamera(g.Arena.Width, g.Arena.Height)
 
@@ -58,9 +145,11 @@ func (g *Game) generateSquares(squaresAmount int) {
 
 	// generate bots for the rest part of the Squares
 	for i := 0; i < squaresAmount; i++ {
-		bot := entity.NewBot(g.GenerateUniqueId())
+		bot := entity.NewBot(g.GenerateUniqueId(), g.random)
 		bot.Position = g.Arena.Spawns[i]
 		bot.Spawn = g.Arena.Spawns[i]
+		g.setTeam(bot, g.Arena.SpawnTeam(i))
+		bot.ApplyStats()
 		g.Squares[bot.Id] = bot
 	}
 }
@@ -72,7 +161,7 @@ func (g *Game) generateSquares(squaresAmount int) {
 func (g *Game) GenerateUniqueId() int64 {
 	var id int64
 	for {
-		id = rand.Int63()
+		id = g.random.Int63()
 		exists := false
 
 		// go through the squares
@@ -91,3 +180,40 @@ func (g *Game) GenerateUniqueId() int64 {
 	}
 	return id
 }
+
+// Random returns the game's random source.
+func (g *Game) Random() *rand.Rand {
+	return g.random
+}
+
+// SortedSquares returns the game squares sorted by id.
+// Maps are iterated in random order, so the squares
+// are updated in this order to make the game reproducible.
+// The returned slice is reused by the next call.
+func (g *Game) SortedSquares() []*entity.Square {
+	g.sortedSquares = g.sortedSquares[:0]
+	for _, s := range g.Squares {
+		g.sortedSquares = append(g.sortedSquares, s)
+	}
+
+	sort.Slice(g.sortedSquares, func(i, j int) bool {
+		return g.sortedSquares[i].Id < g.sortedSquares[j].Id
+	})
+
+	return g.sortedSquares
+}
+
+// SortedObstacles returns the arena obstacles sorted by id.
+// The returned slice is reused by the next call.
+func (g *Game) SortedObstacles() []*arena.Obstacle {
+	g.sortedObstacles = g.sortedObstacles[:0]
+	for _, o := range g.Arena.Obstacles {
+		g.sortedObstacles = append(g.sortedObstacles, o)
+	}
+
+	sort.Slice(g.sortedObstacles, func(i, j int) bool {
+		return g.sortedObstacles[i].Id < g.sortedObstacles[j].Id
+	})
+
+	return g.sortedObstacles
+}
@@ -0,0 +1,100 @@
+package game
+
+import (
+	"github.com/chewxy/math32"
+	"online_shooter/internal/config"
+	"online_shooter/internal/game/arena"
+	"online_shooter/internal/game/entity"
+	"online_shooter/internal/game/geometry"
+	"online_shooter/internal/game/spatial"
+)
+
+// gridCellSizeFactor shows how many times
+// the grid's cell is bigger than the biggest object
+const gridCellSizeFactor = 2
+
+type grids struct {
+	squares   *spatial.Grid[*entity.Square]
+	obstacles *spatial.Grid[*arena.Obstacle]
+	// bullets stores squares by the positions of their bullets
+	bullets *spatial.Grid[*entity.Square]
+
+	// buffers for the query results
+	foundSquares   []*entity.Square
+	foundObstacles []*arena.Obstacle
+}
+
+// initGrids creates the grids covering the game arena.
+func (g *Game) initGrids() {
+	cellSize := gridCellSizeFactor * math32.Max(config.SquareSize(), config.ObstacleSize())
+
+	// squares can't move further than their size during
+	// the tick otherwise they would go through each other
+	slack := config.SquareSize()
+
+	g.grids = grids{
+		squares:   spatial.NewGrid[*entity.Square](g.Arena.Width, g.Arena.Height, cellSize, slack),
+		obstacles: spatial.NewGrid[*arena.Obstacle](g.Arena.Width, g.Arena.Height, cellSize, slack),
+		bullets:   spatial.NewGrid[*entity.Square](g.Arena.Width, g.Arena.Height, cellSize, slack),
+	}
+}
+
+// UpdateGrids refills the grids with the current
+// positions of the squares, their bullets and the obstacles.
+// It must be called once per tick before the collision checks.
+func (g *Game) UpdateGrids() {
+	g.grids.squares.Clear()
+	g.grids.obstacles.Clear()
+	g.grids.bullets.Clear()
+
+	for _, s := range g.SortedSquares() {
+		// the eliminated and the dead squares are out of the game
+		if s.Eliminated || s.Dead {
+			continue
+		}
+
+		g.grids.squares.Insert(s, s.Position, s.Size)
+		for _, b := range s.Bullets {
+			if b != nil {
+				g.grids.bullets.Insert(s, b.Position, b.Size)
+			}
+		}
+	}
+
+	for _, o := range g.SortedObstacles() {
+		g.grids.obstacles.Insert(o, o.Position, o.Size)
+	}
+}
+
+// QuerySquares finds the squares which or which bullets
+// can be inside the area. The result can contain duplicates
+// and the squares outside the area, so callers must
+// check the exact intersection themselves.
+//
+// Accepts the area and a slice to append the found squares to.
+//
+// Returns the slice with the found squares.
+func (g *Game) QuerySquares(area geometry.Rect, found []*entity.Square) []*entity.Square {
+	found = g.grids.squares.Query(area, found)
+	return g.grids.bullets.Query(area, found)
+}
+
+// QueryObstacles finds the obstacles which can be inside the area.
+// The result can contain the obstacles outside the area,
+// so callers must check the exact intersection themselves.
+//
+// Accepts the area and a slice to append the found obstacles to.
+//
+// Returns the slice with the found obstacles.
+func (g *Game) QueryObstacles(area geometry.Rect, found []*arena.Obstacle) []*arena.Obstacle {
+	return g.grids.obstacles.Query(area, found)
+}
+
+// objectArea creates the area covered by a square object.
+//
+// Accepts the object's position and size.
+//
+// Returns the area.
+func objectArea(position geometry.Point, size float32) geometry.Rect {
+	return geometry.Rect{X: position.X, Y: position.Y, Width: size, Height: size}
+}
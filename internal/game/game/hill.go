@@ -0,0 +1,152 @@
+package game
+
+import (
+	"online_shooter/internal/config"
+	"online_shooter/internal/game/entity"
+	"online_shooter/internal/game/geometry"
+	"online_shooter/internal/game/timer"
+	"online_shooter/internal/model"
+	"time"
+)
+
+const (
+	hillZonesAmount = 2
+	// hillZoneSizeFactor is a size of the zone in the square sizes
+	hillZoneSizeFactor = 4
+	// hillRotationInterval is a time after which
+	// the zones move to new places
+	hillRotationInterval = 45 * time.Second
+)
+
+// kingOfTheHill is the mode where the teams fight for the control
+// zones. The team alone in a zone gets a point for every second
+// of holding it, the zones move to new places periodically.
+// The team with the most points wins the round.
+type kingOfTheHill struct {
+	scores   []int
+	rotation timer.Timer
+}
+
+// newKingOfTheHill creates the control zones
+// and places them on the arena.
+//
+// Accepts a pointer to the game.
+//
+// Returns a pointer to the created mode.
+func newKingOfTheHill(g *Game) *kingOfTheHill {
+	size := config.SquareSize() * hillZoneSizeFactor
+	g.Zones = make([]*entity.Zone, hillZonesAmount)
+	for i := range g.Zones {
+		g.Zones[i] = entity.NewZone(geometry.Point{}, size)
+	}
+
+	m := &kingOfTheHill{}
+	m.start(g)
+
+	return m
+}
+
+func (m *kingOfTheHill) start(g *Game) {
+	m.scores = make([]int, g.teams)
+	m.placeZones(g)
+}
+
+// update moves the zones when it is time,
+// finds the holders of the zones and counts their points.
+func (m *kingOfTheHill) update(g *Game, dt time.Duration) {
+	if m.rotation.Advance(dt) {
+		m.placeZones(g)
+	}
+
+	for _, zone := range g.Zones {
+		team, contested := zoneOccupant(g, zone)
+		if zone.Hold(team, contested, dt) {
+			m.scores[team-1]++
+		}
+	}
+}
+
+func (m *kingOfTheHill) kill(*Game, *entity.Square, *entity.Square) {}
+
+func (m *kingOfTheHill) finished(g *Game) bool {
+	return g.teamReachedScoreLimit(m.scores)
+}
+
+// leader finds the team with the most points.
+func (m *kingOfTheHill) leader(*Game) winner {
+	return winner{team: bestTeam(m.scores)}
+}
+
+func (m *kingOfTheHill) teamScores() []int {
+	return m.scores
+}
+
+func (m *kingOfTheHill) fillStatus(*model.MatchStatus) {}
+
+// objective sends the bot to the nearest zone its team
+// doesn't hold, the bot stays in the nearest zone
+// if its team holds all of them.
+func (m *kingOfTheHill) objective(g *Game, bot *entity.Square) (geometry.Point, bool) {
+	var target *entity.Zone
+	var minDistance float32
+	targetHeld := true
+	for _, zone := range g.Zones {
+		held := zone.Holder == bot.Team && !zone.Contested
+		distance := geometry.GetDistanceBetweenTwoPoints(bot.Position, zone.Position)
+
+		// prefer the zones which aren't held by the team
+		if target == nil || targetHeld && !held ||
+			targetHeld == held && distance < minDistance {
+			target = zone
+			targetHeld = held
+			minDistance = distance
+		}
+	}
+	if target == nil {
+		return geometry.Point{}, false
+	}
+
+	// center the bot in the zone
+	center := target.Center()
+	return geometry.Point{X: center.X - bot.Size/2, Y: center.Y - bot.Size/2}, true
+}
+
+// placeZones moves the zones to random places splitting
+// the arena into vertical strips, so the zones don't overlap,
+// and restarts the rotation timer.
+//
+// Accepts a pointer to the game.
+func (m *kingOfTheHill) placeZones(g *Game) {
+	strip := g.Arena.Width / float32(len(g.Zones))
+	for i, zone := range g.Zones {
+		zone.Move(geometry.Point{
+			X: strip*float32(i) + g.random.Float32()*(strip-zone.Size),
+			Y: g.random.Float32() * (g.Arena.Height - zone.Size),
+		})
+	}
+
+	m.rotation.Start(hillRotationInterval)
+}
+
+// zoneOccupant finds the team whose squares are inside the zone.
+//
+// Accepts a pointer to the game and a pointer to the zone.
+//
+// Returns the team or zero if the zone is empty
+// and true if squares of several teams are inside the zone.
+func zoneOccupant(g *Game, zone *entity.Zone) (int, bool) {
+	team := 0
+	for _, square := range g.SortedSquares() {
+		if square.Dead || !isCollision(square.Position, zone.Position, square.Size, zone.Size) {
+			continue
+		}
+
+		if team == 0 {
+			team = square.Team
+		} else if square.Team != team {
+			return team, true
+		}
+	}
+
+	return team, false
+}
@@ -0,0 +1,168 @@
+package game
+
+import (
+	"online_shooter/internal/game/entity"
+	"online_shooter/internal/game/geometry"
+	"online_shooter/internal/game/timer"
+	"online_shooter/internal/model"
+	"time"
+)
+
+// InfectedTeam is the team of the infected squares,
+// the healthy squares are in the SurvivorsTeam
+const InfectedTeam = 2
+
+const (
+	// infectionDuration is a time the survivors must survive
+	// if the round has no time limit
+	infectionDuration = 3 * time.Minute
+	// the infected squares are faster
+	// and fight with the short-range claws
+	infectedSpeedFactor = 1.3
+)
+
+// infection is the mode where one random square starts infected
+// and every square killed by the infected joins them. The survivors
+// win if somebody survives till the time is over, the infected win
+// when they have infected everybody.
+type infection struct {
+	// timer of the round if the match has no time limit
+	timer    timer.Timer
+	timedOut bool
+	scores   []int
+}
+
+// newInfection gives the infected team its stats
+// and infects the first square.
+//
+// Accepts a pointer to the game.
+//
+// Returns a pointer to the created mode.
+func newInfection(g *Game) *infection {
+	stats := g.stats
+	stats.Speed *= infectedSpeedFactor
+	stats.Weapon = entity.Claws
+	g.teamStats = map[int]entity.Stats{InfectedTeam: stats}
+
+	m := &infection{}
+	m.start(g)
+
+	return m
+}
+
+// start heals every square and infects a random one.
+func (m *infection) start(g *Game) {
+	for _, square := range g.SortedSquares() {
+		g.setTeam(square, SurvivorsTeam)
+		square.ApplyStats()
+	}
+	m.infectRandom(g)
+
+	m.timedOut = false
+	m.timer.Stop()
+	if g.match.duration <= 0 {
+		m.timer.Start(infectionDuration)
+	}
+	m.countTeams(g)
+}
+
+// update counts the teams, ends the round when the time
+// is over and infects a new square if the infected have left.
+func (m *infection) update(g *Game, dt time.Duration) {
+	if m.timer.Advance(dt) {
+		m.timedOut = true
+	}
+
+	m.countTeams(g)
+	if m.scores[InfectedTeam-1] == 0 {
+		m.infectRandom(g)
+		m.countTeams(g)
+	}
+}
+
+// kill infects the survivor killed by the infected,
+// the victim respawns with the infected stats.
+func (m *infection) kill(g *Game, killer, victim *entity.Square) {
+	if killer != nil && killer.Team == InfectedTeam && victim.Team == SurvivorsTeam {
+		g.setTeam(victim, InfectedTeam)
+	}
+}
+
+// finished reports whether the time is over
+// or every square is infected.
+func (m *infection) finished(g *Game) bool {
+	return m.timedOut || len(g.Squares) > 0 && m.scores[SurvivorsTeam-1] == 0
+}
+
+// leader returns the survivors if somebody
+// is still healthy and the infected otherwise.
+func (m *infection) leader(*Game) winner {
+	if m.scores[SurvivorsTeam-1] > 0 {
+		return winner{team: SurvivorsTeam}
+	}
+	return winner{team: InfectedTeam}
+}
+
+// teamScores returns the amounts of the survivors and the infected.
+func (m *infection) teamScores() []int {
+	return m.scores
+}
+
+func (m *infection) fillStatus(*model.MatchStatus) {}
+
+// objective sends the infected bot to the nearest survivor.
+func (m *infection) objective(g *Game, bot *entity.Square) (geometry.Point, bool) {
+	if bot.Team != InfectedTeam {
+		return geometry.Point{}, false
+	}
+
+	var target *entity.Square
+	var minDistance float32
+	for _, square := range g.SortedSquares() {
+		if square.Team != SurvivorsTeam || square.Dead {
+			continue
+		}
+		distance := geometry.GetDistanceBetweenTwoPoints(bot.Position, square.Position)
+		if target == nil || distance < minDistance {
+			target = square
+			minDistance = distance
+		}
+	}
+	if target == nil {
+		return geometry.Point{}, false
+	}
+
+	return target.Position, true
+}
+
+// infectRandom infects a random survivor.
+//
+// Accepts a pointer to the game.
+func (m *infection) infectRandom(g *Game) {
+	var survivors []*entity.Square
+	for _, square := range g.SortedSquares() {
+		if square.Team == SurvivorsTeam {
+			survivors = append(survivors, square)
+		}
+	}
+	if len(survivors) == 0 {
+		return
+	}
+
+	square := survivors[g.random.Intn(len(survivors))]
+	g.setTeam(square, InfectedTeam)
+	square.ApplyStats()
+}
+
+// countTeams counts the survivors and the infected.
+//
+// Accepts a pointer to the game.
+func (m *infection) countTeams(g *Game) {
+	if m.scores == nil {
+		m.scores = make([]int, sidesAmount)
+	}
+	clear(m.scores)
+	for _, square := range g.Squares {
+		m.scores[square.Team-1]++
+	}
+}
@@ -0,0 +1,39 @@
+package game
+
+import (
+	"online_shooter/internal/game/entity"
+	"online_shooter/internal/model"
+)
+
+// recordKill gives the assists to the squares which have damaged
+// the victim during the assist window and records the kill event.
+// It must be called before the mode changes the victim's team.
+//
+// Accepts a pointer to the killer which is nil if the victim
+// is killed not by a square and a pointer to the victim.
+func (g *Game) recordKill(killer, victim *entity.Square) {
+	event := model.KillEvent{Victim: victim.Id}
+	if killer != nil {
+		event.Killer = killer.Id
+		event.Weapon = killer.WeaponKind
+		event.Streak = killer.Combat.Streak
+	}
+
+	for _, id := range victim.Attackers() {
+		// the killer and the squares which have left get no assist
+		assistant := g.Squares[id]
+		if id == event.Killer || assistant == nil {
+			continue
+		}
+		assistant.Combat.Assists++
+		event.Assists = append(event.Assists, id)
+	}
+
+	g.killEvents = append(g.killEvents, event)
+}
+
+// KillEvents returns the kills of the last step
+// in the order they have happened.
+func (g *Game) KillEvents() []model.KillEvent {
+	return g.killEvents
+}
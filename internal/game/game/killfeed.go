@@ -0,0 +1,42 @@
+package game
+
+import (
+	"online_shooter/internal/model"
+	"time"
+)
+
+const (
+	// killFeedDuration is a time the kill is shown in the kill feed
+	killFeedDuration = 5 * time.Second
+	// killFeedSize is the max amount of the kills in the kill feed
+	killFeedSize = 5
+)
+
+// KillFeedEntry is the kill shown in the client's kill feed.
+type KillFeedEntry struct {
+	model.KillEvent
+	Received time.Time
+}
+
+// Expired checks if the kill has been shown long enough.
+//
+// Accepts the current time.
+func (e KillFeedEntry) Expired(now time.Time) bool {
+	return now.Sub(e.Received) >= killFeedDuration
+}
+
+// AddKills adds the kills received from the server to
+// the kill feed and forgets the expired and the oldest ones.
+//
+// Accepts the received kills and the time they are received.
+func (g *Game) AddKills(kills []model.KillEvent, now time.Time) {
+	for _, kill := range kills {
+		g.KillFeed = append(g.KillFeed, KillFeedEntry{KillEvent: kill, Received: now})
+	}
+
+	start := max(len(g.KillFeed)-killFeedSize, 0)
+	for start < len(g.KillFeed) && g.KillFeed[start].Expired(now) {
+		start++
+	}
+	g.KillFeed = g.KillFeed[start:]
+}
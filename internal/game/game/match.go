@@ -0,0 +1,147 @@
+package game
+
+import (
+	"online_shooter/internal/game/timer"
+	"online_shooter/internal/model"
+	"slices"
+	"time"
+)
+
+// states of the match
+const (
+	MatchWarmup    = "warmup"
+	MatchCountdown = "countdown"
+	MatchLive      = "live"
+	MatchEnded     = "ended"
+)
+
+const (
+	warmupDuration       = 15 * time.Second
+	countdownDuration    = 5 * time.Second
+	intermissionDuration = 10 * time.Second
+)
+
+// match is the state machine of the rounds. During the warmup
+// squares play without the limits, during the countdown and
+// after the round ends they are frozen. A round ends when its time
+// is over or the game mode ends it, for example when the score limit
+// is reached, then the final scoreboard is shown till the next countdown.
+// A match without the time and the score limits is always live.
+type match struct {
+	state      string
+	duration   time.Duration
+	scoreLimit int
+	timer      timer.Timer
+	// winner is the winner of the last round
+	winner winner
+}
+
+// initMatch inits the match state machine with the limits
+// from the settings and starts the warmup if the rounds are enabled.
+//
+// Accepts a pointer to the server settings instance.
+func (g *Game) initMatch(settings *ServerSettings) {
+	g.match = match{
+		state:      MatchLive,
+		duration:   settings.MatchDuration,
+		scoreLimit: settings.ScoreLimit,
+	}
+
+	// play without the rounds if there are no limits
+	if g.match.duration <= 0 && g.match.scoreLimit <= 0 {
+		return
+	}
+
+	g.match.state = MatchWarmup
+	g.match.timer.Start(warmupDuration)
+}
+
+// updateMatch advances the match timer and switches
+// the match to the next state when it is time.
+//
+// Accepts the elapsed game time.
+func (g *Game) updateMatch(dt time.Duration) {
+	finished := g.match.timer.Advance(dt)
+
+	switch g.match.state {
+	case MatchWarmup:
+		if finished {
+			g.startCountdown()
+		}
+
+	case MatchCountdown:
+		if finished {
+			g.startRound()
+		}
+
+	case MatchLive:
+		if finished || g.mode.finished(g) {
+			g.endRound(g.mode.leader(g))
+		}
+
+	case MatchEnded:
+		if finished {
+			g.startCountdown()
+		}
+	}
+}
+
+// startCountdown freezes the squares till the round starts.
+func (g *Game) startCountdown() {
+	g.match.state = MatchCountdown
+	g.match.timer.Start(countdownDuration)
+}
+
+// startRound resets the squares' stats, returns them
+// to their spawn points, returns the pickups
+// and starts the round timer.
+func (g *Game) startRound() {
+	g.match.state = MatchLive
+	g.match.winner = winner{}
+
+	for _, square := range g.Squares {
+		square.Restart()
+	}
+	g.resetPickups()
+	g.mode.start(g)
+
+	if g.match.duration > 0 {
+		g.match.timer.Start(g.match.duration)
+	}
+}
+
+// endRound freezes the squares and starts the intermission
+// showing the final scoreboard.
+//
+// Accepts the winner of the round.
+func (g *Game) endRound(winner winner) {
+	g.match.state = MatchEnded
+	g.match.winner = winner
+	g.match.timer.Start(intermissionDuration)
+
+	// remove the bullets in the air
+	for _, square := range g.Squares {
+		square.ClearBullets()
+	}
+}
+
+// Frozen reports whether the squares can't move and shoot
+// because the round is starting or has ended.
+func (g *Game) Frozen() bool {
+	return g.match.state == MatchCountdown || g.match.state == MatchEnded
+}
+
+// MatchStatus returns the state of the match to send it to the clients.
+func (g *Game) MatchStatus() *model.MatchStatus {
+	status := &model.MatchStatus{
+		Mode:       g.modeName,
+		State:      g.match.state,
+		Remaining:  g.match.timer.Remaining(),
+		TeamScores: slices.Clone(g.mode.teamScores()),
+		Winner:     g.match.winner.id,
+		WinnerTeam: g.match.winner.team,
+	}
+	g.mode.fillStatus(status)
+
+	return status
+}
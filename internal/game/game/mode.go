@@ -0,0 +1,178 @@
+package game
+
+import (
+	"online_shooter/internal/game/entity"
+	"online_shooter/internal/game/geometry"
+	"online_shooter/internal/model"
+	"time"
+)
+
+// game modes
+const (
+	ModeDeathmatch     = "deathmatch"
+	ModeTeamDeathmatch = "team deathmatch"
+	ModeCaptureTheFlag = "capture the flag"
+	ModeKingOfTheHill  = "king of the hill"
+	ModeBattleRoyale   = "battle royale"
+	ModeSurvival       = "survival"
+	ModeInfection      = "infection"
+)
+
+// Modes lists the game modes in the order
+// they are switched in the menu.
+var Modes = []string{
+	ModeDeathmatch,
+	ModeTeamDeathmatch,
+	ModeCaptureTheFlag,
+	ModeKingOfTheHill,
+	ModeBattleRoyale,
+	ModeSurvival,
+	ModeInfection,
+}
+
+// teamModes lists the game modes played in teams
+var teamModes = []string{
+	ModeTeamDeathmatch,
+	ModeCaptureTheFlag,
+	ModeKingOfTheHill,
+}
+
+// winner is a winner of the round. The id is zero
+// if a team wins or nobody does, the team is zero
+// if a square wins or nobody does.
+type winner struct {
+	id   int64
+	team int
+}
+
+// mode is the rules of a game mode. The match
+// state machine asks the mode about the end
+// and the winner of the round and notifies it
+// about the round's events.
+type mode interface {
+	// start prepares the mode for a new round
+	start(g *Game)
+
+	// update updates the mode's state every tick of the round
+	update(g *Game, dt time.Duration)
+
+	// kill counts the kill of the victim by the killer
+	// which is nil if the victim is killed not by a square,
+	// the mode can eliminate the victim instead of respawning it
+	kill(g *Game, killer, victim *entity.Square)
+
+	// finished reports whether the round is over
+	// before its time is over
+	finished(g *Game) bool
+
+	// leader returns the winner of the round if it ends now
+	leader(g *Game) winner
+
+	// teamScores returns the scores of the teams by their
+	// order or nil if the squares play on their own
+	teamScores() []int
+
+	// objective returns the point the bot goes to
+	// instead of roaming and false if there is no objective
+	objective(g *Game, bot *entity.Square) (geometry.Point, bool)
+
+	// fillStatus adds the mode's state to the match status
+	fillStatus(status *model.MatchStatus)
+}
+
+// newMode creates the rules of the game mode.
+//
+// Accepts a pointer to the game with the arena
+// and the squares created for the mode
+// and a pointer to the server settings instance.
+//
+// Returns the rules of the mode.
+func newMode(g *Game, settings *ServerSettings) mode {
+	switch g.modeName {
+	case ModeTeamDeathmatch:
+		return &teamDeathmatch{scores: make([]int, g.teams)}
+	case ModeCaptureTheFlag:
+		return newCaptureTheFlag(g)
+	case ModeKingOfTheHill:
+		return newKingOfTheHill(g)
+	case ModeBattleRoyale:
+		return newBattleRoyale(g, settings)
+	case ModeSurvival:
+		return newSurvival(g)
+	case ModeInfection:
+		return newInfection(g)
+	default:
+		return &deathmatch{}
+	}
+}
+
+// killSquare records the kill, lets the game mode count it and
+// unless the mode has eliminated the victim respawns it
+// after the respawn delay or at once if there is no delay.
+//
+// Accepts a pointer to the killer which is nil if the victim
+// is killed not by a square and a pointer to the victim.
+func (g *Game) killSquare(killer, victim *entity.Square) {
+	g.recordKill(killer, victim)
+	g.mode.kill(g, killer, victim)
+	if victim.Eliminated {
+		return
+	}
+
+	if g.respawnDelay > 0 {
+		victim.Die(killer, g.respawnDelay)
+	} else {
+		victim.Respawn(g.spawnPoint(victim))
+	}
+}
+
+// deathmatch is the mode where every square plays on its own
+// and the square with the most kills wins the round.
+type deathmatch struct{}
+
+func (m *deathmatch) start(*Game) {}
+
+func (m *deathmatch) update(*Game, time.Duration) {}
+
+func (m *deathmatch) kill(*Game, *entity.Square, *entity.Square) {}
+
+func (m *deathmatch) finished(g *Game) bool {
+	if g.match.scoreLimit <= 0 {
+		return false
+	}
+
+	for _, square := range g.Squares {
+		if int(square.Kills) >= g.match.scoreLimit {
+			return true
+		}
+	}
+
+	return false
+}
+
+// leader finds the square with the most kills and the fewest deaths.
+// Nobody wins if nobody has killed.
+func (m *deathmatch) leader(g *Game) winner {
+	var leader *entity.Square
+	for _, square := range g.SortedSquares() {
+		if leader == nil || square.Kills > leader.Kills ||
+			square.Kills == leader.Kills && square.Deaths < leader.Deaths {
+			leader = square
+		}
+	}
+
+	if leader == nil || leader.Kills == 0 {
+		return winner{}
+	}
+	return winner{id: leader.Id}
+}
+
+func (m *deathmatch) teamScores() []int {
+	return nil
+}
+
+func (m *deathmatch) objective(*Game, *entity.Square) (geometry.Point, bool) {
+	return geometry.Point{}, false
+}
+
+func (m *deathmatch) fillStatus(*model.MatchStatus) {}
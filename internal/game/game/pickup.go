@@ -0,0 +1,85 @@
+package game
+
+import (
+	"online_shooter/internal/game/entity"
+	"online_shooter/internal/game/geometry"
+	"time"
+)
+
+const (
+	// botPickupHealthLevel is a part of the max health
+	// below which the bot looks for the health packs
+	botPickupHealthLevel = 0.5
+	// botPickupZoneLevel is a distance in the bot's sizes
+	// to the pickup the bot takes even if it doesn't lack anything
+	botPickupZoneLevel = 6
+)
+
+// updatePickups returns the taken pickups when it is time
+// and gives the effects of the pickups to the squares touching them.
+//
+// Accepts the elapsed game time.
+func (g *Game) updatePickups(dt time.Duration) {
+	for _, pickup := range g.Arena.Pickups {
+		pickup.UpdateTimers(dt)
+		if !pickup.Active {
+			continue
+		}
+
+		// the first square in the order takes the pickup
+		for _, square := range g.SortedSquares() {
+			if square.Eliminated || square.Dead || !isCollision(square.Position, pickup.Position, square.Size, pickup.Size) {
+				continue
+			}
+			if pickup.Apply(square) {
+				break
+			}
+		}
+	}
+}
+
+// resetPickups returns every pickup to the arena.
+func (g *Game) resetPickups() {
+	for _, pickup := range g.Arena.Pickups {
+		pickup.Reset()
+	}
+}
+
+// pickupTarget finds the pickup the bot goes to: the nearest
+// health pack if the bot is badly damaged, the nearest ammo
+// if the bot's weapon is out of ammo, or any useful pickup nearby.
+//
+// Accepts a pointer to the bot.
+//
+// Returns the position to go to and false if the bot doesn't need a pickup.
+func (g *Game) pickupTarget(bot *entity.Square) (geometry.Point, bool) {
+	needsHealth := float32(bot.Health) < float32(bot.Stats.Health)*botPickupHealthLevel
+	needsAmmo := bot.OutOfAmmo()
+
+	var target *entity.Pickup
+	var minDistance float32
+	for _, pickup := range g.Arena.Pickups {
+		if !pickup.Active || !pickup.Useful(bot) {
+			continue
+		}
+
+		distance := geometry.GetDistanceBetweenTwoPoints(bot.Position, pickup.Position)
+		needed := needsHealth && pickup.Kind == entity.HealthPickup ||
+			needsAmmo && pickup.Kind == entity.AmmoPickup
+		if !needed && distance > bot.Size*botPickupZoneLevel {
+			continue
+		}
+
+		if target == nil || distance < minDistance {
+			target = pickup
+			minDistance = distance
+		}
+	}
+	if target == nil {
+		return geometry.Point{}, false
+	}
+
+	// center the bot on the pickup
+	center := target.Center()
+	return geometry.Point{X: center.X - bot.Size/2, Y: center.Y - bot.Size/2}, true
+}
@@ -0,0 +1,95 @@
+package game
+
+import (
+	"online_shooter/internal/game/entity"
+)
+
+// AddPlayer adds a new player to the game swapping
+// it with the weakest bot. In the team modes the player
+// joins the team with the fewest players, in the survival
+// the player is added without swapping.
+//
+// Returns a pointer to the added player or nil
+// if there are no bots in the game.
+func (g *Game) AddPlayer() *entity.Square {
+	if g.modeName == ModeSurvival {
+		return g.addSurvivor()
+	}
+
+	// find the weakest bot to remove it from the game
+	bot := g.FindWeakestBot(g.teamForPlayer())
+	if bot == nil {
+		return nil
+	}
+
+	// create and init a new player instance
+	player := entity.NewPlayer(g.random)
+
+	// generate an id
+	player.Id = g.GenerateUniqueId()
+
+	// transfer the bot's spawn point to the player
+	player.Spawn = bot.Spawn
+
+	// set the player's position to its spawn point
+	player.Position = player.Spawn
+
+	// transfer the bot's team to the player
+	g.setTeam(player, bot.Team)
+	player.ApplyStats()
+
+	// the player replacing the eliminated bot waits for the next round
+	if bot.Eliminated {
+		player.Eliminate()
+	}
+
+	// delete the bot
+	delete(g.Squares, bot.Id)
+
+	// add the player to the game
+	g.Squares[player.Id] = player
+
+	return player
+}
+
+// RemovePlayer removes a player with accepted id
+// from the game replacing it with a new bot
+// everywhere except the survival.
+//
+// Accepts an id of the player that should be removed.
+//
+// Returns true if the player is removed, false if
+// there is no player with such id.
+func (g *Game) RemovePlayer(id int64) bool {
+	player, ok := g.Squares[id]
+	if !ok || player.IsBot {
+		return false
+	}
+
+	// delete player
+	delete(g.Squares, id)
+
+	// the bots of the survival come in waves
+	if g.modeName == ModeSurvival {
+		return true
+	}
+
+	// create and init a new bot instance
+	bot := entity.NewBot(g.GenerateUniqueId(), g.random)
+
+	// transfer the deleted player's spawn point and team to the bot,
+	// so the teams stay balanced
+	bot.Spawn = player.Spawn
+	g.setTeam(bot, player.Team)
+	bot.ApplyStats()
+
+	// the bot replacing the eliminated player waits for the next round
+	if player.Eliminated {
+		bot.Eliminate()
+	}
+
+	// add the created bot to the game
+	g.Squares[bot.Id] = bot
+
+	return true
+}
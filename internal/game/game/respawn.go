@@ -0,0 +1,93 @@
+package game
+
+import (
+	"github.com/chewxy/math32"
+	"online_shooter/internal/game/entity"
+	"online_shooter/internal/game/geometry"
+	"time"
+)
+
+const (
+	defaultRespawnDelay = 3 * time.Second
+	// objectiveRespawnDelay is a respawn delay in the modes
+	// with the objectives, so the defenders can't come back at once
+	objectiveRespawnDelay = 5 * time.Second
+	// infectionRespawnDelay is a respawn delay of the infection
+	// where the killed survivors come back as the infected
+	infectionRespawnDelay = time.Second
+)
+
+// initRespawn sets the delay before the killed squares respawn.
+// The mode gives its own delay and the delay
+// set in the settings replaces the mode's one.
+//
+// Accepts a pointer to the server settings instance.
+func (g *Game) initRespawn(settings *ServerSettings) {
+	delay := defaultRespawnDelay
+	switch g.modeName {
+	case ModeCaptureTheFlag, ModeKingOfTheHill:
+		delay = objectiveRespawnDelay
+	case ModeInfection:
+		delay = infectionRespawnDelay
+	}
+
+	g.respawnDelay = overrideSetting(delay, settings.RespawnDelay)
+}
+
+// updateRespawn counts down the respawn of the dead square
+// and respawns it at the safest spawn point when it is time.
+//
+// Accepts a pointer to the dead square and the elapsed game time.
+func (g *Game) updateRespawn(square *entity.Square, dt time.Duration) {
+	if square.UpdateRespawn(dt) {
+		square.Respawn(g.spawnPoint(square))
+	}
+}
+
+// spawnPoint chooses the spawn point of the square's team
+// which is farthest from the enemies and isn't blocked by
+// an obstacle. The square's own spawn point is preferred
+// when the points are equally safe.
+//
+// Accepts a pointer to the square.
+//
+// Returns the chosen spawn point or the square's
+// own one if every spawn point is blocked.
+func (g *Game) spawnPoint(square *entity.Square) geometry.Point {
+	best := square.Spawn
+	bestDistance := float32(-1)
+	for _, spawn := range append([]geometry.Point{square.Spawn}, g.Arena.TeamSpawns(square.Team)...) {
+		// the obstacle would push the square out of the spawn point
+		if collision, _ := g.checkCollisionWithObstacles(spawn, square.Size); collision {
+			continue
+		}
+
+		distance := g.enemyDistance(square, spawn)
+		if distance > bestDistance {
+			best = spawn
+			bestDistance = distance
+		}
+	}
+
+	return best
+}
+
+// enemyDistance finds the distance from the point
+// to the nearest alive enemy of the square.
+// The squares are taken from the map as the order
+// doesn't change the nearest distance.
+//
+// Accepts a pointer to the square and the point.
+//
+// Returns the distance which is infinite if there are no enemies.
+func (g *Game) enemyDistance(square *entity.Square, point geometry.Point) float32 {
+	minDistance := math32.Inf(1)
+	for _, enemy := range g.Squares {
+		if enemy == square || enemy.Eliminated || enemy.Dead || square.IsTeammate(enemy) {
+			continue
+		}
+		minDistance = min(minDistance, geometry.GetDistanceBetweenTwoPoints(point, enemy.Position))
+	}
+
+	return minDistance
+}
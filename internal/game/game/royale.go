@@ -0,0 +1,222 @@
+package game
+
+import (
+	"github.com/chewxy/math32"
+	"online_shooter/internal/game/entity"
+	"online_shooter/internal/game/geometry"
+	"online_shooter/internal/game/timer"
+	"online_shooter/internal/model"
+	"time"
+)
+
+const (
+	defaultZoneWait   = 30 * time.Second
+	defaultZoneShrink = 20 * time.Second
+	// royalePhases is an amount of the safe zone's shrinkings
+	royalePhases = 4
+	// royaleShrinkFactor is a part of the radius
+	// the safe zone keeps after the shrinking
+	royaleShrinkFactor = 0.5
+	// royaleZoneDamage is a damage taken outside the safe zone
+	// every damage interval, it grows with every phase
+	royaleZoneDamage     = 5
+	royaleDamageInterval = time.Second
+)
+
+// battleRoyale is the mode where every square plays on its own
+// without respawns. The safe zone shrinks in phases and damages
+// the squares outside it. The last square standing wins the round.
+type battleRoyale struct {
+	wait   time.Duration
+	shrink time.Duration
+
+	phase int
+	// timer of the current wait or shrinking
+	timer  timer.Timer
+	damage timer.Timer
+	// the circle the zone shrinks from
+	fromCenter geometry.Point
+	fromRadius float32
+}
+
+// newBattleRoyale creates the safe zone covering the arena.
+//
+// Accepts a pointer to the game and a pointer
+// to the server settings with the timings of the phases.
+//
+// Returns a pointer to the created mode.
+func newBattleRoyale(g *Game, settings *ServerSettings) *battleRoyale {
+	m := &battleRoyale{
+		wait:   settings.ZoneWait,
+		shrink: settings.ZoneShrink,
+	}
+	if m.wait <= 0 {
+		m.wait = defaultZoneWait
+	}
+	if m.shrink <= 0 {
+		m.shrink = defaultZoneShrink
+	}
+
+	g.SafeZone = &entity.SafeZone{}
+	m.start(g)
+
+	return m
+}
+
+// start returns the safe zone to the whole arena
+// and starts the wait of the first phase.
+func (m *battleRoyale) start(g *Game) {
+	m.phase = 0
+	*g.SafeZone = entity.SafeZone{
+		Center: geometry.Point{X: g.Arena.Width / 2, Y: g.Arena.Height / 2},
+		Radius: math32.Hypot(g.Arena.Width, g.Arena.Height) / 2,
+	}
+	m.nextCircle(g)
+	m.timer.Start(m.wait)
+	m.damage.Start(royaleDamageInterval)
+	g.SafeZone.Remaining = m.timer.Remaining()
+}
+
+// update shrinks the safe zone when it is time
+// and damages the squares outside it.
+func (m *battleRoyale) update(g *Game, dt time.Duration) {
+	zone := g.SafeZone
+
+	if m.timer.Advance(dt) {
+		if zone.Shrinking {
+			// the phase is over, wait before the next one
+			zone.Center, zone.Radius = zone.NextCenter, zone.NextRadius
+			zone.Shrinking = false
+			m.phase++
+			if m.phase < royalePhases {
+				m.nextCircle(g)
+				m.timer.Start(m.wait)
+			}
+		} else {
+			// start shrinking to the next circle
+			m.fromCenter, m.fromRadius = zone.Center, zone.Radius
+			zone.Shrinking = true
+			m.timer.Start(m.shrink)
+		}
+	}
+
+	// move the circle towards the next one
+	if zone.Shrinking {
+		progress := 1 - float32(m.timer.Remaining())/float32(m.shrink)
+		zone.Center = geometry.Point{
+			X: m.fromCenter.X + (zone.NextCenter.X-m.fromCenter.X)*progress,
+			Y: m.fromCenter.Y + (zone.NextCenter.Y-m.fromCenter.Y)*progress,
+		}
+		zone.Radius = m.fromRadius + (zone.NextRadius-m.fromRadius)*progress
+	}
+	zone.Remaining = m.timer.Remaining()
+
+	// damage the squares outside the zone
+	if !m.damage.Advance(dt) {
+		return
+	}
+	m.damage.Start(royaleDamageInterval)
+	damage := int32(royaleZoneDamage * (m.phase + 1))
+	for _, square := range g.SortedSquares() {
+		if square.Eliminated || zone.Contains(square.Position, square.Size) {
+			continue
+		}
+		if square.TakeDamage(damage) {
+			g.killSquare(nil, square)
+		}
+	}
+}
+
+// kill eliminates the victim till the next round.
+func (m *battleRoyale) kill(_ *Game, _, victim *entity.Square) {
+	victim.Eliminate()
+}
+
+// finished reports whether only one square
+// or nobody is left in the round.
+func (m *battleRoyale) finished(g *Game) bool {
+	return len(g.Squares) > 1 && g.aliveSquares() <= 1
+}
+
+// leader finds the last square standing, if several
+// squares are alive the one with the most kills wins.
+func (m *battleRoyale) leader(g *Game) winner {
+	var leader *entity.Square
+	for _, square := range g.SortedSquares() {
+		if !square.Eliminated && (leader == nil || square.Kills > leader.Kills) {
+			leader = square
+		}
+	}
+
+	if leader == nil {
+		return winner{}
+	}
+	return winner{id: leader.Id}
+}
+
+func (m *battleRoyale) teamScores() []int {
+	return nil
+}
+
+func (m *battleRoyale) fillStatus(*model.MatchStatus) {}
+
+// objective sends the bot into the next circle
+// if the bot is outside it.
+func (m *battleRoyale) objective(g *Game, bot *entity.Square) (geometry.Point, bool) {
+	zone := g.SafeZone
+	next := entity.SafeZone{Center: zone.NextCenter, Radius: zone.NextRadius - bot.Size}
+	if next.Contains(bot.Position, bot.Size) {
+		return geometry.Point{}, false
+	}
+
+	return geometry.Point{X: zone.NextCenter.X - bot.Size/2, Y: zone.NextCenter.Y - bot.Size/2}, true
+}
+
+// nextCircle chooses the next circle of the safe zone
+// at a random place inside the current one.
+//
+// Accepts a pointer to the game.
+func (m *battleRoyale) nextCircle(g *Game) {
+	zone := g.SafeZone
+	zone.NextRadius = zone.Radius * royaleShrinkFactor
+
+	angle := g.random.Float32() * 2 * math32.Pi
+	offset := g.random.Float32() * (zone.Radius - zone.NextRadius)
+	zone.NextCenter = geometry.Point{
+		X: zone.Center.X + math32.Cos(angle)*offset,
+		Y: zone.Center.Y + math32.Sin(angle)*offset,
+	}
+}
+
+// aliveSquares counts the squares which aren't eliminated.
+func (g *Game) aliveSquares() int {
+	alive := 0
+	for _, square := range g.Squares {
+		if !square.Eliminated {
+			alive++
+		}
+	}
+	return alive
+}
+
+// SpectatedSquare finds the square the eliminated
+// player watches which is the nearest alive square.
+//
+// Accepts a pointer to the eliminated player.
+//
+// Returns a pointer to the square or nil if nobody is alive.
+func (g *Game) SpectatedSquare(player *entity.Square) *entity.Square {
+	var target *entity.Square
+	var minDistance float32
+	for _, square := range g.SortedSquares() {
+		if square.Eliminated || square.Dead {
+			continue
+		}
+		distance := geometry.GetDistanceBetweenTwoPoints(player.Position, square.Position)
+		if target == nil || distance < minDistance {
+			target = square
+			minDistance = distance
+		}
+	}
+	return target
+}
@@ -0,0 +1,51 @@
+package game
+
+import "time"
+
+type ServerSettings struct {
+	PlayerCount   int
+	ObstacleLevel string
+	IsPublic      bool
+	TLSMode       string
+	// Ricochet makes bullets bounce off obstacles
+	Ricochet bool
+	// Record makes the server record the match
+	Record bool
+	// Seed is a seed of the game's random source,
+	// a new seed is generated if it is zero,
+	// so zero is never a seed of a game
+	Seed int64
+	// MatchDuration is a time limit of a round
+	// and ScoreLimit is a score of the game mode which ends it,
+	// the match isn't split into rounds if both are zero
+	MatchDuration time.Duration
+	ScoreLimit    int
+	// Mode is a name of the game mode, Teams is
+	// an amount of the teams in the team modes
+	// and FriendlyFire makes the bullets hit the teammates
+	Mode         string
+	Teams        int
+	FriendlyFire bool
+	// ZoneWait is a time the safe zone of the battle royale
+	// waits before every shrinking and ZoneShrink is a time
+	// of the shrinking, the defaults are used if they are zero
+	ZoneWait   time.Duration
+	ZoneShrink time.Duration
+	// DashInvulnerable makes the squares invulnerable while they dash
+	DashInvulnerable bool
+	// Armor is the armor the squares respawn with, HitSlowdown is
+	// a part of the speed every hit takes till the square recovers
+	// in SlowdownRecovery and Regeneration is the health restored
+	// every second after RegenerationDelay out of combat.
+	// The mode's rules are used if they are zero,
+	// a negative value turns the rule off
+	Armor             int32
+	HitSlowdown       float32
+	SlowdownRecovery  time.Duration
+	Regeneration      int32
+	RegenerationDelay time.Duration
+	// RespawnDelay is a time the killed squares wait before
+	// they respawn, the mode's delay is used if it is zero
+	// and the squares respawn at once if it is negative
+	RespawnDelay time.Duration
+}
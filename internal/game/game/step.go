@@ -0,0 +1,167 @@
+package game
+
+import (
+	"math/rand"
+	"online_shooter/internal/game/entity"
+	"online_shooter/internal/game/geometry"
+	"online_shooter/internal/model"
+	"time"
+)
+
+// TickDuration is the game time of one tick. The server
+// steps the game with it, so a recorded match
+// can be simulated again tick by tick.
+const TickDuration = time.Second / 60
+
+// Step updates the game state by one tick.
+// Bots make their decisions and players are updated
+// using the inputs from the clients. While the round
+// is starting or has ended only the match timer is updated.
+//
+// Accepts the players' inputs by their ids where
+// a player without an input keeps its state
+// and the elapsed game time.
+func (g *Game) Step(inputs map[int64]*model.PlayerUpdateMessage, dt time.Duration) {
+	// forget the kills of the previous step
+	g.killEvents = g.killEvents[:0]
+
+	// switch the match state if it is time
+	g.updateMatch(dt)
+	if g.Frozen() {
+		return
+	}
+
+	// count the speed correction value
+	deltaTime := float32(dt.Seconds())
+
+	// fill the grids with the current positions
+	g.UpdateGrids()
+
+	// advance the obstacles' timers
+	for _, obstacle := range g.Arena.Obstacles {
+		obstacle.UpdateTimers(dt)
+	}
+
+	// go through every square in the game in the same order and update its state
+	for _, square := range g.SortedSquares() {
+		// the eliminated squares wait for the next round
+		if square.Eliminated {
+			continue
+		}
+
+		// the dead squares wait for the respawn
+		if square.Dead {
+			g.updateRespawn(square, dt)
+			continue
+		}
+
+		square.UpdateTimers(dt)
+
+		// the square burned to death may be eliminated or wait for the respawn
+		if square.UpdateEffects(dt) {
+			g.killSquare(nil, square)
+			if square.Eliminated || square.Dead {
+				continue
+			}
+		}
+
+		if square.IsBot {
+			// change game's state for the bot
+			enemy, distance := g.FindEnemy(square)
+			if dodge, ok := g.dodgeVector(square); ok {
+				square.Dash(dodge)
+			}
+			square.Move(g.CountMovingVector(square, enemy, distance), deltaTime)
+			aim := g.CountShootingPoint(enemy, distance)
+			if aim != nil {
+				square.Shoot(*aim, g.random)
+			}
+			g.ReloadBot(square, enemy, distance)
+		} else if input := inputs[square.Id]; input != nil {
+			// change game's state for the player
+			applyInput(square, input, deltaTime, g.random)
+		}
+
+		g.CheckSquareCollision(square)
+		square.UpdateBullets(deltaTime)
+		g.CheckBulletsCollision(square)
+	}
+
+	// give the pickups to the squares touching them
+	g.updatePickups(dt)
+
+	// apply the rules of the game mode
+	g.mode.update(g, dt)
+}
+
+// MergeInput merges the player's input received after the pending
+// one before the same tick. The newer input replaces the pending one,
+// but the actions pressed for one frame are kept, so they aren't
+// lost when the next frame's input comes before the tick.
+//
+// Accepts pointers to the pending input which is nil
+// if there is none and the newer input.
+//
+// Returns a pointer to the merged input.
+func MergeInput(pending, next *model.PlayerUpdateMessage) *model.PlayerUpdateMessage {
+	if pending == nil {
+		return next
+	}
+
+	merged := *next
+	if merged.Weapon == 0 {
+		merged.Weapon = pending.Weapon
+	}
+	merged.Reload = merged.Reload || pending.Reload
+	merged.Dash = merged.Dash || pending.Dash
+
+	return &merged
+}
+
+// applyInput updates the player's square state
+// using the information from the client.
+//
+// Accepts a pointer to the player instance,
+// a pointer to the instance with a square's state update,
+// a delta time value to correct the player's square speed
+// and a pointer to the game's random source.
+func applyInput(player *entity.Square, upd *model.PlayerUpdateMessage, deltaTime float32, random *rand.Rand) {
+	// change player's position
+	vector := &geometry.Vector{}
+	if upd.UpKeyPressed {
+		vector.Y--
+	}
+	if upd.DownKeyPressed {
+		vector.Y++
+	}
+	if upd.LeftKeyPressed {
+		vector.X--
+	}
+	if upd.RightKeyPressed {
+		vector.X++
+	}
+
+	vector.Normalize()
+
+	// the dash takes the direction of the movement
+	if upd.Dash {
+		player.Dash(*vector)
+	}
+	player.Move(*vector, deltaTime)
+
+	// switch the weapon, the slots start from one
+	if upd.Weapon > 0 {
+		player.SwitchWeapon(entity.WeaponKind(upd.Weapon - 1))
+	}
+
+	if upd.Reload {
+		player.Reload()
+	}
+
+	// make player shoot
+	if upd.Shot {
+		player.Shoot(upd.Aim, random)
+	} else {
+		player.CanShoot = true
+	}
+}
@@ -0,0 +1,307 @@
+package game
+
+import (
+	"online_shooter/internal/config"
+	"online_shooter/internal/game/entity"
+	"online_shooter/internal/game/geometry"
+	"online_shooter/internal/game/timer"
+	"online_shooter/internal/model"
+	"time"
+)
+
+// teams of the survival
+const (
+	SurvivorsTeam = 1
+	WavesTeam     = 2
+)
+
+const (
+	survivalLives = 10
+	survivalBreak = 10 * time.Second
+	// the first wave has survivalFirstWave bots
+	// and every next wave has survivalWaveGrowth more
+	survivalFirstWave  = 3
+	survivalWaveGrowth = 2
+	// survivalHealthGrowth and survivalFireRateGrowth are
+	// the percents the bots' health and fire rate grow with every wave
+	survivalHealthGrowth   = 20
+	survivalFireRateGrowth = 15
+	// survivalWaveSpacing is a distance between the bots
+	// sharing the spawn point as a part of the bot's size
+	survivalWaveSpacing = 1.5
+	// survivalPlaceTries is an amount of the places
+	// tried for the bot near the spawn point
+	survivalPlaceTries = 10
+)
+
+// survival is the cooperative mode where the players fight
+// the waves of bots together. Every wave has more bots
+// which are stronger and shoot faster, the waves are split by
+// the short breaks. The killed players respawn while the shared lives
+// are left, the round is over when every player is eliminated.
+type survival struct {
+	wave  int
+	lives int
+	// fighting shows if the wave is in progress,
+	// otherwise the break timer counts down to the next wave
+	fighting   bool
+	breakTimer timer.Timer
+}
+
+// newSurvival creates the wave director.
+//
+// Accepts a pointer to the game.
+//
+// Returns a pointer to the created mode.
+func newSurvival(g *Game) *survival {
+	m := &survival{}
+	m.start(g)
+
+	return m
+}
+
+// start removes the bots of the previous round,
+// restores the lives and starts the break before the first wave.
+func (m *survival) start(g *Game) {
+	for _, square := range g.SortedSquares() {
+		if square.IsBot {
+			delete(g.Squares, square.Id)
+		}
+	}
+
+	m.wave = 0
+	m.lives = survivalLives
+	m.fighting = false
+	m.breakTimer.Start(survivalBreak)
+}
+
+// update despawns the killed bots, ends the wave when every
+// bot is killed and spawns the next wave after the break.
+// The break doesn't count down while there are no players.
+func (m *survival) update(g *Game, dt time.Duration) {
+	bots := 0
+	for _, square := range g.SortedSquares() {
+		if !square.IsBot {
+			continue
+		}
+		if square.Eliminated {
+			delete(g.Squares, square.Id)
+			continue
+		}
+		bots++
+	}
+
+	if m.fighting {
+		if bots == 0 {
+			m.fighting = false
+			m.breakTimer.Start(survivalBreak)
+		}
+		return
+	}
+
+	if g.players() > 0 && m.breakTimer.Advance(dt) {
+		m.spawnWave(g)
+	}
+}
+
+// kill removes the killed bot and spends a shared life
+// to respawn the killed player, the player is eliminated
+// if there are no lives left.
+func (m *survival) kill(_ *Game, _, victim *entity.Square) {
+	if !victim.IsBot && m.lives > 0 {
+		m.lives--
+		return
+	}
+
+	victim.Eliminate()
+}
+
+// finished reports whether every player is eliminated.
+func (m *survival) finished(g *Game) bool {
+	return g.players() > 0 && g.alivePlayers() == 0
+}
+
+// leader returns the players' team if somebody
+// has survived and the bots' team otherwise.
+func (m *survival) leader(g *Game) winner {
+	if g.alivePlayers() > 0 {
+		return winner{team: SurvivorsTeam}
+	}
+	return winner{team: WavesTeam}
+}
+
+func (m *survival) teamScores() []int {
+	return nil
+}
+
+// fillStatus adds the wave, the lives and the time till the next wave.
+func (m *survival) fillStatus(status *model.MatchStatus) {
+	status.Wave = m.wave
+	status.Lives = m.lives
+	if !m.fighting {
+		status.WaveBreak = m.breakTimer.Remaining()
+	}
+}
+
+// objective sends the bot to the nearest alive player.
+func (m *survival) objective(g *Game, bot *entity.Square) (geometry.Point, bool) {
+	var target *entity.Square
+	var minDistance float32
+	for _, square := range g.SortedSquares() {
+		if square.IsBot || square.Eliminated || square.Dead {
+			continue
+		}
+		distance := geometry.GetDistanceBetweenTwoPoints(bot.Position, square.Position)
+		if target == nil || distance < minDistance {
+			target = square
+			minDistance = distance
+		}
+	}
+	if target == nil {
+		return geometry.Point{}, false
+	}
+
+	return target.Position, true
+}
+
+// spawnWave spawns the bots of the next wave with random
+// weapons at the spawn points of the bots' team. The bots
+// which don't fit at the spawn points are placed next to them.
+//
+// Accepts a pointer to the game.
+func (m *survival) spawnWave(g *Game) {
+	m.wave++
+	m.fighting = true
+
+	growth := int32(m.wave - 1)
+	health := config.SquareHealth() * (100 + survivalHealthGrowth*growth) / 100
+
+	spawns := g.Arena.TeamSpawns(WavesTeam)
+	count := survivalFirstWave + survivalWaveGrowth*(m.wave-1)
+	for i := 0; i < count; i++ {
+		bot := entity.NewBot(g.GenerateUniqueId(), g.random)
+		bot.Spawn = spawns[i%len(spawns)]
+		g.setTeam(bot, WavesTeam)
+		bot.Stats.Health = health
+		bot.ApplyStats()
+		bot.Position = g.wavePosition(bot.Spawn, bot.Size)
+
+		// every bot gets a random weapon which shoots faster in the later waves
+		bot.Equip(entity.WeaponKind(g.random.Intn(entity.PlayerWeapons)))
+		bot.Weapon.FireRate = bot.Weapon.FireRate * 100 / time.Duration(100+survivalFireRateGrowth*growth)
+		g.Squares[bot.Id] = bot
+	}
+}
+
+// wavePosition finds a free place for the bot of the wave near
+// the spawn point. The bots sharing the spawn point line up from it
+// towards the center of the arena skipping the places taken
+// by the obstacles and the other squares.
+//
+// Accepts the spawn point and the size of the bot.
+//
+// Returns the free place or the spawn point if there is none.
+func (g *Game) wavePosition(spawn geometry.Point, size float32) geometry.Point {
+	direction := geometry.Vector{X: g.Arena.Width/2 - spawn.X, Y: g.Arena.Height/2 - spawn.Y}
+	direction.Normalize()
+	step := size * survivalWaveSpacing
+
+	for i := 0; i < survivalPlaceTries; i++ {
+		position := geometry.Point{
+			X: spawn.X + direction.X*step*float32(i),
+			Y: spawn.Y + direction.Y*step*float32(i),
+		}
+		// the spawn points are on the edges of the arena
+		g.checkCollisionWithBorders(&position, size)
+
+		if collision, _ := g.checkCollisionWithObstacles(position, size); collision || g.isPlaceTaken(position, size) {
+			continue
+		}
+		return position
+	}
+
+	return spawn
+}
+
+// isPlaceTaken checks if the place is taken by a square
+// which is in the game. The squares are taken from the map
+// as the order doesn't change the result.
+//
+// Accepts the position and the size of the place.
+func (g *Game) isPlaceTaken(position geometry.Point, size float32) bool {
+	for _, square := range g.Squares {
+		if !square.Eliminated && !square.Dead && isCollision(position, square.Position, size, square.Size) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// addSurvivor adds a new player to the survival
+// at the free spawn point of the players' team.
+//
+// Returns a pointer to the added player or nil
+// if the game has the maximum amount of players.
+func (g *Game) addSurvivor() *entity.Square {
+	spawn, ok := g.freeSurvivorSpawn()
+	if !ok {
+		return nil
+	}
+
+	player := entity.NewPlayer(g.random)
+	player.Id = g.GenerateUniqueId()
+	player.Spawn = spawn
+	player.Position = player.Spawn
+	g.setTeam(player, SurvivorsTeam)
+	player.ApplyStats()
+	g.Squares[player.Id] = player
+
+	return player
+}
+
+// freeSurvivorSpawn finds the first spawn point of the players' team
+// which isn't taken by a player, the point of the player who has left
+// is free again. The squares are taken from the map as the order
+// doesn't change whether the point is taken.
+//
+// Returns the free spawn point and true
+// or false if every spawn point is taken.
+func (g *Game) freeSurvivorSpawn() (geometry.Point, bool) {
+	for _, spawn := range g.Arena.TeamSpawns(SurvivorsTeam) {
+		taken := false
+		for _, square := range g.Squares {
+			if !square.IsBot && square.Spawn == spawn {
+				taken = true
+				break
+			}
+		}
+		if !taken {
+			return spawn, true
+		}
+	}
+
+	return geometry.Point{}, false
+}
+
+// players counts the players in the game.
+func (g *Game) players() int {
+	players := 0
+	for _, square := range g.Squares {
+		if !square.IsBot {
+			players++
+		}
+	}
+	return players
+}
+
+// alivePlayers counts the players which aren't eliminated.
+func (g *Game) alivePlayers() int {
+	alive := 0
+	for _, square := range g.Squares {
+		if !square.IsBot && !square.Eliminated {
+			alive++
+		}
+	}
+	return alive
+}
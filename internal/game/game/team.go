@@ -0,0 +1,192 @@
+package game
+
+import (
+	"image/color"
+	"online_shooter/internal/game/entity"
+	"online_shooter/internal/game/geometry"
+	"online_shooter/internal/model"
+	"online_shooter/internal/utils"
+	"slices"
+	"time"
+)
+
+const (
+	defaultTeams = 2
+	maxTeams     = 4
+	// sidesAmount is an amount of the teams
+	// in the modes with two sides
+	sidesAmount = 2
+)
+
+// teamColors are the colors of the teams by their order
+var teamColors = []color.RGBA{
+	{R: 230, G: 60, B: 60, A: 255},
+	{R: 60, G: 120, B: 240, A: 255},
+	{R: 60, G: 200, B: 90, A: 255},
+	{R: 240, G: 200, B: 40, A: 255},
+}
+
+// teamNames are the names of the teams by their order
+var teamNames = []string{"RED", "BLUE", "GREEN", "YELLOW"}
+
+// TeamColor returns the color of the team.
+//
+// Accepts the team starting from one.
+func TeamColor(team int) color.RGBA {
+	return teamColors[(team-1)%maxTeams]
+}
+
+// TeamName returns the name of the team.
+//
+// Accepts the team starting from one.
+func TeamName(team int) string {
+	return teamNames[(team-1)%maxTeams]
+}
+
+// parseTeams returns the amount of the teams
+// in the game mode.
+//
+// Accepts a pointer to the server settings instance.
+//
+// Returns zero if the squares play on their own.
+func parseTeams(settings *ServerSettings) int {
+	// the squares are split into two sides
+	// in the survival and the infection
+	if settings.Mode == ModeSurvival || settings.Mode == ModeInfection {
+		return sidesAmount
+	}
+	if !slices.Contains(teamModes, settings.Mode) {
+		return 0
+	}
+	if settings.Teams < defaultTeams {
+		return defaultTeams
+	}
+	return min(settings.Teams, maxTeams)
+}
+
+// setTeam moves the square to the team, gives it
+// the team's stats and tints its color with the team's color.
+// The stats are applied when the square respawns.
+//
+// Accepts a pointer to the square and the team
+// which is zero if the square plays on its own.
+func (g *Game) setTeam(square *entity.Square, team int) {
+	square.Team = team
+	square.Stats = g.TeamStats(team)
+
+	if team != 0 {
+		square.SetColor(utils.TintedColor(TeamColor(team), g.random))
+	}
+}
+
+// teamForPlayer chooses the team for a new player
+// which has the fewest players and still has bots
+// the player can replace, so the players are split
+// between the teams evenly.
+//
+// Returns the team or zero if the squares play on their own.
+func (g *Game) teamForPlayer() int {
+	if g.teams == 0 {
+		return 0
+	}
+
+	// count the players and the bots of every team
+	players := make([]int, g.teams+1)
+	bots := make([]int, g.teams+1)
+	for _, square := range g.Squares {
+		if square.IsBot {
+			bots[square.Team]++
+		} else {
+			players[square.Team]++
+		}
+	}
+
+	team := 0
+	for t := 1; t <= g.teams; t++ {
+		if bots[t] > 0 && (team == 0 || players[t] < players[team]) {
+			team = t
+		}
+	}
+	return team
+}
+
+// teamDeathmatch is the mode where the squares play in teams
+// and the team with the most kills of the enemies wins the round.
+type teamDeathmatch struct {
+	scores []int
+}
+
+func (m *teamDeathmatch) start(g *Game) {
+	m.scores = make([]int, g.teams)
+}
+
+func (m *teamDeathmatch) update(*Game, time.Duration) {}
+
+func (m *teamDeathmatch) kill(_ *Game, killer, victim *entity.Square) {
+	if killer != nil && !killer.IsTeammate(victim) {
+		m.scores[killer.Team-1]++
+	}
+}
+
+func (m *teamDeathmatch) finished(g *Game) bool {
+	return g.teamReachedScoreLimit(m.scores)
+}
+
+// leader finds the team with the highest score.
+// Nobody wins if the best teams have the same score.
+func (m *teamDeathmatch) leader(*Game) winner {
+	return winner{team: bestTeam(m.scores)}
+}
+
+func (m *teamDeathmatch) teamScores() []int {
+	return m.scores
+}
+
+func (m *teamDeathmatch) objective(*Game, *entity.Square) (geometry.Point, bool) {
+	return geometry.Point{}, false
+}
+
+func (m *teamDeathmatch) fillStatus(*model.MatchStatus) {}
+
+// teamReachedScoreLimit checks if any team
+// has reached the score limit of the round.
+//
+// Accepts the scores of the teams by their order.
+//
+// Returns false if there is no score limit.
+func (g *Game) teamReachedScoreLimit(scores []int) bool {
+	if g.match.scoreLimit <= 0 {
+		return false
+	}
+
+	for _, score := range scores {
+		if score >= g.match.scoreLimit {
+			return true
+		}
+	}
+
+	return false
+}
+
+// bestTeam finds the team with the highest score.
+//
+// Accepts the scores of the teams by their order.
+//
+// Returns the team or zero if several teams have the highest score.
+func bestTeam(scores []int) int {
+	best := 0
+	draw := false
+	for i, score := range scores {
+		if best == 0 || score > scores[best-1] {
+			best = i + 1
+			draw = false
+		} else if score == scores[best-1] {
+			draw = true
+		}
+	}
+
+	if draw {
+		return 0
+	}
+	return best
+}
@@ -0,0 +1,22 @@
+package geometry
+
+type Rect struct {
+	X, Y          float32
+	Width, Height float32
+}
+
+// Intersects checks if a square object
+// intersects with the rectangle.
+//
+// Accepts the object's position and size.
+//
+// Returns true if there is an intersection, otherwise false.
+func (r Rect) Intersects(position Point, size float32) bool {
+	return position.X+size >= r.X && position.X <= r.X+r.Width &&
+		position.Y+size >= r.Y && position.Y <= r.Y+r.Height
+}
+
+// Center returns the center of the rectangle.
+func (r Rect) Center() Point {
+	return Point{X: r.X + r.Width/2, Y: r.Y + r.Height/2}
+}
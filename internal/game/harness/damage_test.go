@@ -0,0 +1,34 @@
+package harness
+
+import (
+	"online_shooter/internal/config"
+	"online_shooter/internal/game/game"
+	"online_shooter/internal/game/geometry"
+	"testing"
+	"time"
+)
+
+func TestServerDamageRules(t *testing.T) {
+	h := newModeScene(game.ModeDeathmatch, func(s *game.ServerSettings) {
+		s.Armor = 20
+		s.HitSlowdown = -1
+		s.Regeneration = 10
+		s.RegenerationDelay = time.Second
+	})
+	shooter := h.AddPlayer(geometry.Point{X: 100, Y: 300})
+	victim := h.AddPlayer(geometry.Point{X: 400, Y: 300})
+
+	// two hits take the armor and a part of the health without slowing the victim down
+	h.Run(60, shootEveryOtherTick(shooter.Id, victim.Position))
+	damage := 2*config.BulletDamage() - 20
+	if victim.Armor != 0 || victim.Health != config.SquareHealth()-damage || victim.EffectiveSpeed() != victim.Speed {
+		t.Fatalf("got armor %d, health %d and speed %v, want the armor taken first and no slowdown",
+			victim.Armor, victim.Health, victim.EffectiveSpeed())
+	}
+
+	// the health regenerates out of combat
+	runFor(h, 4*time.Second)
+	if victim.Health != config.SquareHealth() {
+		t.Fatalf("got health %d, want the health regenerated", victim.Health)
+	}
+}
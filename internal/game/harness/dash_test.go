@@ -0,0 +1,78 @@
+package harness
+
+import (
+	"online_shooter/internal/config"
+	"online_shooter/internal/game/arena"
+	"online_shooter/internal/game/game"
+	"online_shooter/internal/game/geometry"
+	"online_shooter/internal/model"
+	"testing"
+)
+
+func TestPlayerDashes(t *testing.T) {
+	h := newScene(false)
+	dasher := h.AddPlayer(geometry.Point{X: 100, Y: 100})
+	walker := h.AddPlayer(geometry.Point{X: 100, Y: 300})
+
+	// both players go right, one of them dashes on every tick
+	script := func(tick int) map[int64]*model.PlayerUpdateMessage {
+		return map[int64]*model.PlayerUpdateMessage{
+			dasher.Id: {RightKeyPressed: true, Dash: true},
+			walker.Id: {RightKeyPressed: true},
+		}
+	}
+	h.Run(30, script)
+
+	if dasher.Position.X <= walker.Position.X || dasher.DashReady() {
+		t.Fatalf("got dasher at %v and walker at %v, want the dasher ahead", dasher.Position, walker.Position)
+	}
+
+	// the dash doesn't repeat till the cooldown is over
+	lead := dasher.Position.X - walker.Position.X
+	h.Run(30, script)
+	if dasher.Position.X-walker.Position.X != lead {
+		t.Fatalf("got lead %v, want %v during the cooldown", dasher.Position.X-walker.Position.X, lead)
+	}
+}
+
+func TestDashInvulnerability(t *testing.T) {
+	h := New(game.ServerSettings{
+		PlayerCount:      4,
+		ObstacleLevel:    arena.LowObstaclesAmount,
+		Seed:             1,
+		DashInvulnerable: true,
+	})
+	h.Clear()
+	shooter := h.AddPlayer(geometry.Point{X: 100, Y: 300})
+	dasher := h.AddPlayer(geometry.Point{X: 220, Y: 300})
+
+	// the bullet reaches the dashing player
+	script := func(tick int) map[int64]*model.PlayerUpdateMessage {
+		return map[int64]*model.PlayerUpdateMessage{
+			shooter.Id: {Shot: tick == 1, Aim: geometry.Point{X: 240, Y: 320}},
+			dasher.Id:  {LeftKeyPressed: true, Dash: tick == 1},
+		}
+	}
+	h.Run(10, script)
+
+	if dasher.Health != config.SquareHealth() {
+		t.Fatalf("got health %d, want the dashing player to be invulnerable", dasher.Health)
+	}
+}
+
+func TestBotDodgesBullet(t *testing.T) {
+	h := newScene(false)
+	shooter := h.AddPlayer(geometry.Point{X: 100, Y: 300})
+	bot := h.AddBot(geometry.Point{X: 400, Y: 300})
+
+	script := func(tick int) map[int64]*model.PlayerUpdateMessage {
+		return map[int64]*model.PlayerUpdateMessage{
+			shooter.Id: {Shot: tick == 1, Aim: geometry.Point{X: 420, Y: 320}},
+		}
+	}
+	h.Run(30, script)
+
+	if bot.DashReady() || bot.Health != config.SquareHealth() {
+		t.Fatalf("got health %d, want the bot to dash out of the bullet's way", bot.Health)
+	}
+}
@@ -0,0 +1,71 @@
+package harness
+
+import (
+	"online_shooter/internal/game/game"
+	"online_shooter/internal/game/geometry"
+	"testing"
+	"time"
+)
+
+func TestCaptureTheFlag(t *testing.T) {
+	h := newModeScene(game.ModeCaptureTheFlag)
+	red, blue := h.Game.Flags[0], h.Game.Flags[1]
+
+	// the blue player takes the red flag
+	player := h.AddPlayer(red.Position)
+	player.Team = 2
+	h.Step(nil)
+	if red.Carrier != player.Id {
+		t.Fatalf("got the flag carried by %d, want %d", red.Carrier, player.Id)
+	}
+
+	// and brings it to the blue base
+	player.Position = blue.Base.Center()
+	h.Step(nil)
+	if scores := h.Game.MatchStatus().TeamScores; scores[1] != 1 || !red.AtBase || red.Carrier != 0 {
+		t.Fatalf("got scores %v and the flag at base %v, want a capture", scores, red.AtBase)
+	}
+}
+
+func TestDroppedFlagReturns(t *testing.T) {
+	h := newModeScene(game.ModeCaptureTheFlag)
+	red := h.Game.Flags[0]
+
+	// the blue player takes the red flag and is killed
+	carrier := h.AddPlayer(red.Position)
+	carrier.Team = 2
+	carrier.Health = 10
+	carrier.Spawn = geometry.Point{X: 600, Y: 600}
+	h.Step(nil)
+	dropped := red.Position
+
+	shooter := h.AddPlayer(geometry.Point{X: carrier.Position.X + 200, Y: carrier.Position.Y})
+	shooter.Team = 1
+	h.Run(30, shootEveryOtherTick(shooter.Id, carrier.Position))
+	if carrier.Deaths != 1 || red.Carrier != 0 || red.AtBase || red.Position != dropped {
+		t.Fatalf("got deaths %d and the flag carried by %d at %v, want the flag dropped at %v",
+			carrier.Deaths, red.Carrier, red.Position, dropped)
+	}
+
+	// the dropped flag returns after the timeout
+	runFor(h, 15*time.Second)
+	if !red.AtBase {
+		t.Fatal("the dropped flag hasn't returned")
+	}
+}
+
+func TestTeammateReturnsFlag(t *testing.T) {
+	h := newModeScene(game.ModeCaptureTheFlag)
+	red := h.Game.Flags[0]
+	red.Take(h.AddBot(geometry.Point{X: 600, Y: 600}))
+	red.Drop()
+	h.Clear()
+
+	// the red player touches the dropped flag
+	player := h.AddPlayer(red.Position)
+	player.Team = 1
+	h.Step(nil)
+	if !red.AtBase {
+		t.Fatal("the teammate hasn't returned the flag")
+	}
+}
@@ -0,0 +1,175 @@
+package harness
+
+import (
+	"online_shooter/internal/config"
+	"online_shooter/internal/game/arena"
+	"online_shooter/internal/game/entity"
+	"online_shooter/internal/game/game"
+	"online_shooter/internal/game/geometry"
+	"online_shooter/internal/model"
+	"time"
+)
+
+// DefaultDeltaTime is the game time of one tick
+// equal to the server's tick duration.
+const DefaultDeltaTime = game.TickDuration
+
+// Script returns the players' inputs for the tick.
+// Players without an input keep their state.
+type Script func(tick int) map[int64]*model.PlayerUpdateMessage
+
+// Harness drives the game without a server,
+// network connections or a window.
+// It steps the game with the constant delta time,
+// so the same settings, seed and script
+// always lead to the same game state.
+type Harness struct {
+	Game      *game.Game
+	Tick      int
+	DeltaTime time.Duration
+}
+
+// New creates a harness with a new game.
+//
+// Accepts the server settings with the seed of the game.
+//
+// Returns a pointer to the created harness.
+func New(settings game.ServerSettings) *Harness {
+	h := &Harness{
+		Game:      &game.Game{},
+		DeltaTime: DefaultDeltaTime,
+	}
+	h.Game.InitServerGame(&settings)
+
+	return h
+}
+
+// Clear removes every square, obstacle and pickup
+// from the game to build a scene from scratch.
+func (h *Harness) Clear() {
+	h.Game.Squares = make(map[int64]*entity.Square)
+	h.Game.Arena.Obstacles = make(map[int64]*arena.Obstacle)
+	h.Game.Arena.Pickups = nil
+}
+
+// AddObstacle adds a new obstacle to the game.
+//
+// Accepts a position of the obstacle.
+//
+// Returns a pointer to the added obstacle.
+func (h *Harness) AddObstacle(position geometry.Point) *arena.Obstacle {
+	// take an id which is not used by obstacles
+	id := int64(len(h.Game.Arena.Obstacles) + 1)
+	for h.Game.Arena.Obstacles[id] != nil {
+		id++
+	}
+
+	o := &arena.Obstacle{
+		Id:         id,
+		Position:   position,
+		Health:     config.ObstacleHealth(),
+		Size:       config.ObstacleSize(),
+		Vulnerable: true,
+	}
+	h.Game.Arena.Obstacles[id] = o
+
+	return o
+}
+
+// AddPickup adds a new pickup to the game.
+//
+// Accepts the kind of the pickup and the center of its place.
+//
+// Returns a pointer to the added pickup.
+func (h *Harness) AddPickup(kind entity.PickupKind, center geometry.Point) *entity.Pickup {
+	pickup := entity.NewPickup(kind, center)
+	h.Game.Arena.Pickups = append(h.Game.Arena.Pickups, pickup)
+
+	return pickup
+}
+
+// AddPlayer adds a new player square to the game
+// with the game's default stats.
+// Unlike the bots players act only on the inputs.
+//
+// Accepts a position of the player which is also its spawn point.
+//
+// Returns a pointer to the added player.
+func (h *Harness) AddPlayer(position geometry.Point) *entity.Square {
+	player := entity.NewPlayer(h.Game.Random())
+	player.Id = h.Game.GenerateUniqueId()
+	player.Position = position
+	player.Spawn = position
+	player.Stats = h.Game.Stats()
+	player.ApplyStats()
+	h.Game.Squares[player.Id] = player
+
+	return player
+}
+
+// AddBot adds a new bot square to the game
+// with the game's default stats.
+//
+// Accepts a position of the bot which is also its spawn point.
+//
+// Returns a pointer to the added bot.
+func (h *Harness) AddBot(position geometry.Point) *entity.Square {
+	bot := entity.NewBot(h.Game.GenerateUniqueId(), h.Game.Random())
+	bot.Position = position
+	bot.Spawn = position
+	bot.Stats = h.Game.Stats()
+	bot.ApplyStats()
+	h.Game.Squares[bot.Id] = bot
+
+	return bot
+}
+
+// Step updates the game by one tick.
+//
+// Accepts the players' inputs for the tick.
+func (h *Harness) Step(inputs map[int64]*model.PlayerUpdateMessage) {
+	h.Game.Step(inputs, h.DeltaTime)
+	h.Tick++
+}
+
+// Run updates the game by the amount of ticks.
+//
+// Accepts the amount of ticks and the script providing
+// the players' inputs which can be nil.
+func (h *Harness) Run(ticks int, script Script) {
+	for i := 0; i < ticks; i++ {
+		var inputs map[int64]*model.PlayerUpdateMessage
+		if script != nil {
+			inputs = script(h.Tick)
+		}
+		h.Step(inputs)
+	}
+}
+
+// Record updates the game by the amount of ticks
+// taking the game snapshots at the interval.
+//
+// Accepts the amount of ticks, the interval between
+// the snapshots in ticks and the script providing
+// the players' inputs which can be nil.
+//
+// Returns the snapshots including the initial and the final ones.
+func (h *Harness) Record(ticks, interval int, script Script) []*Snapshot {
+	snapshots := []*Snapshot{h.Snapshot()}
+	for done := 0; done < ticks; done += interval {
+		h.Run(min(interval, ticks-done), script)
+		snapshots = append(snapshots, h.Snapshot())
+	}
+
+	return snapshots
+}
+
+// Input returns a script sending the same input
+// of the player every tick.
+//
+// Accepts an id of the player and the input.
+func Input(id int64, input model.PlayerUpdateMessage) Script {
+	return func(int) map[int64]*model.PlayerUpdateMessage {
+		return map[int64]*model.PlayerUpdateMessage{id: &input}
+	}
+}
@@ -0,0 +1,185 @@
+package harness
+
+import (
+	"bytes"
+	"flag"
+	"online_shooter/internal/config/configtest"
+	"online_shooter/internal/game/arena"
+	"online_shooter/internal/game/game"
+	"online_shooter/internal/game/geometry"
+	"online_shooter/internal/model"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+var update = flag.Bool("update", false, "update the golden files")
+
+func TestMain(m *testing.M) {
+	configtest.Main(m)
+}
+
+// assertGolden compares the data with the test's golden file
+// or overwrites the file if the -update flag is set.
+func assertGolden(t *testing.T, got []byte) {
+	t.Helper()
+
+	path := filepath.Join("testdata", t.Name()+".golden")
+	if *update {
+		if err := os.WriteFile(path, got, 0644); err != nil {
+			t.Fatal(err)
+		}
+		return
+	}
+
+	want, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("failed to read the golden file, run the test with -update to create it: %v", err)
+	}
+	if !bytes.Equal(got, want) {
+		t.Errorf("the game state differs from %s, run the test with -update if the change is expected\ngot:\n%s", path, got)
+	}
+}
+
+// newScene creates a harness with an empty arena.
+func newScene(ricochet bool) *Harness {
+	h := New(game.ServerSettings{
+		PlayerCount:   4,
+		ObstacleLevel: arena.LowObstaclesAmount,
+		Ricochet:      ricochet,
+		Seed:          1,
+	})
+	h.Clear()
+
+	return h
+}
+
+// newModeScene creates a harness with an empty arena
+// for the game mode with the small set of the squares.
+//
+// Accepts the game mode and the functions
+// changing the rest of the settings.
+func newModeScene(mode string, configure ...func(*game.ServerSettings)) *Harness {
+	settings := game.ServerSettings{
+		PlayerCount:   4,
+		ObstacleLevel: arena.LowObstaclesAmount,
+		Seed:          1,
+		Mode:          mode,
+	}
+	for _, c := range configure {
+		c(&settings)
+	}
+
+	h := New(settings)
+	h.Clear()
+
+	return h
+}
+
+// shootEveryOtherTick returns a script making the player
+// shoot towards the aim every second tick. The player
+// has to release the trigger to shoot again.
+func shootEveryOtherTick(id int64, aim geometry.Point) Script {
+	return func(tick int) map[int64]*model.PlayerUpdateMessage {
+		return map[int64]*model.PlayerUpdateMessage{
+			id: {Shot: tick%2 == 1, Aim: aim},
+		}
+	}
+}
+
+func TestMovement(t *testing.T) {
+	h := newScene(false)
+	player := h.AddPlayer(geometry.Point{X: 100, Y: 100})
+
+	script := Input(player.Id, model.PlayerUpdateMessage{RightKeyPressed: true, DownKeyPressed: true})
+	assertGolden(t, Dump(h.Record(60, 20, script)...))
+}
+
+func TestMovementStopsAtBorder(t *testing.T) {
+	h := newScene(false)
+	player := h.AddPlayer(geometry.Point{X: 20, Y: 100})
+
+	script := Input(player.Id, model.PlayerUpdateMessage{LeftKeyPressed: true, UpKeyPressed: true})
+	assertGolden(t, Dump(h.Record(30, 10, script)...))
+}
+
+func TestObstacleCollision(t *testing.T) {
+	h := newScene(false)
+	h.AddObstacle(geometry.Point{X: 300, Y: 100})
+	player := h.AddPlayer(geometry.Point{X: 200, Y: 120})
+
+	script := Input(player.Id, model.PlayerUpdateMessage{RightKeyPressed: true})
+	assertGolden(t, Dump(h.Record(30, 10, script)...))
+}
+
+func TestSquareCollision(t *testing.T) {
+	h := newScene(false)
+	pusher := h.AddPlayer(geometry.Point{X: 100, Y: 300})
+	h.AddPlayer(geometry.Point{X: 200, Y: 300})
+
+	script := Input(pusher.Id, model.PlayerUpdateMessage{RightKeyPressed: true})
+	assertGolden(t, Dump(h.Record(30, 10, script)...))
+}
+
+func TestBulletDamagesObstacle(t *testing.T) {
+	h := newScene(false)
+	h.AddObstacle(geometry.Point{X: 300, Y: 100})
+	player := h.AddPlayer(geometry.Point{X: 100, Y: 120})
+
+	script := shootEveryOtherTick(player.Id, geometry.Point{X: 340, Y: 120})
+	assertGolden(t, Dump(h.Record(40, 10, script)...))
+}
+
+func TestRicochet(t *testing.T) {
+	h := newScene(true)
+	h.AddObstacle(geometry.Point{X: 300, Y: 100})
+	player := h.AddPlayer(geometry.Point{X: 100, Y: 200})
+
+	// shoot once and let the bullet bounce
+	script := func(tick int) map[int64]*model.PlayerUpdateMessage {
+		return map[int64]*model.PlayerUpdateMessage{
+			player.Id: {Shot: tick == 1, Aim: geometry.Point{X: 340, Y: 140}},
+		}
+	}
+	assertGolden(t, Dump(h.Record(60, 10, script)...))
+}
+
+func TestDamage(t *testing.T) {
+	h := newScene(false)
+	shooter := h.AddPlayer(geometry.Point{X: 100, Y: 300})
+	h.AddPlayer(geometry.Point{X: 400, Y: 300})
+
+	// shoot once
+	script := func(tick int) map[int64]*model.PlayerUpdateMessage {
+		return map[int64]*model.PlayerUpdateMessage{
+			shooter.Id: {Shot: tick == 1, Aim: geometry.Point{X: 420, Y: 320}},
+		}
+	}
+	assertGolden(t, Dump(h.Record(40, 10, script)...))
+}
+
+func TestKillAndRespawn(t *testing.T) {
+	h := newScene(false)
+	shooter := h.AddPlayer(geometry.Point{X: 100, Y: 300})
+	victim := h.AddPlayer(geometry.Point{X: 400, Y: 300})
+
+	// kill the victim with five shots and wait for its respawn
+	script := shootEveryOtherTick(shooter.Id, geometry.Point{X: 420, Y: 320})
+	h.Run(180, script)
+	if victim.Deaths != 1 || shooter.Kills != 1 {
+		t.Fatalf("got %d deaths and %d kills, want the victim to be killed once", victim.Deaths, shooter.Kills)
+	}
+
+	assertGolden(t, Dump(h.Record(240, 60, nil)...))
+}
+
+func TestBotsMatch(t *testing.T) {
+	h := New(game.ServerSettings{
+		PlayerCount:   8,
+		ObstacleLevel: arena.MediumObstaclesAmount,
+		Seed:          1,
+	})
+
+	h.Run(600, nil)
+	assertGolden(t, Dump(h.Snapshot()))
+}
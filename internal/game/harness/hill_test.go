@@ -0,0 +1,44 @@
+package harness
+
+import (
+	"online_shooter/internal/game/game"
+	"testing"
+	"time"
+)
+
+func TestHillHolding(t *testing.T) {
+	h := newModeScene(game.ModeKingOfTheHill)
+	zone := h.Game.Zones[0]
+
+	// the red player holds the zone alone
+	red := h.AddPlayer(zone.Position)
+	red.Team = 1
+	runFor(h, 2*time.Second)
+	if scores := h.Game.MatchStatus().TeamScores; zone.Holder != 1 || scores[0] != 2 {
+		t.Fatalf("got the holder %d and scores %v, want the red team to earn 2 points", zone.Holder, scores)
+	}
+
+	// the blue player contests the zone
+	blue := h.AddPlayer(zone.Position)
+	blue.Team = 2
+	runFor(h, 2*time.Second)
+	if scores := h.Game.MatchStatus().TeamScores; !zone.Contested || scores[0] != 2 || scores[1] != 0 {
+		t.Fatalf("got contested %v and scores %v, want no points while contested", zone.Contested, scores)
+	}
+}
+
+func TestHillRotation(t *testing.T) {
+	h := newModeScene(game.ModeKingOfTheHill)
+	zone := h.Game.Zones[0]
+	before := zone.Position
+
+	red := h.AddPlayer(zone.Position)
+	red.Team = 1
+	runFor(h, 45*time.Second)
+	if zone.Position == before {
+		t.Fatal("the zone hasn't moved")
+	}
+	if scores := h.Game.MatchStatus().TeamScores; scores[0] < 44 {
+		t.Fatalf("got scores %v, want the red team to hold the zone till it moved", scores)
+	}
+}
@@ -0,0 +1,103 @@
+package harness
+
+import (
+	"online_shooter/internal/config"
+	"online_shooter/internal/game/arena"
+	"online_shooter/internal/game/entity"
+	"online_shooter/internal/game/game"
+	"online_shooter/internal/game/geometry"
+	"testing"
+	"time"
+)
+
+func TestInfectionStartsWithOneInfected(t *testing.T) {
+	// the squares generated by the game are kept
+	h := New(game.ServerSettings{
+		PlayerCount:   4,
+		ObstacleLevel: arena.LowObstaclesAmount,
+		Seed:          1,
+		Mode:          game.ModeInfection,
+	})
+
+	infected := 0
+	for _, square := range h.Game.Squares {
+		if square.Team == game.InfectedTeam {
+			infected++
+			if square.Speed <= config.SquareSpeed() || square.WeaponKind != entity.Claws {
+				t.Fatalf("got speed %v and weapon %s, want the infected stats", square.Speed, square.Weapon.Name)
+			}
+		}
+	}
+	if infected != 1 {
+		t.Fatalf("got %d infected squares, want 1", infected)
+	}
+}
+
+func TestInfectedKillConvertsVictim(t *testing.T) {
+	h := newModeScene(game.ModeInfection)
+	stats := h.Game.TeamStats(game.InfectedTeam)
+
+	infected := h.AddPlayer(geometry.Point{X: 100, Y: 300})
+	infected.Team = game.InfectedTeam
+	infected.Stats = stats
+	infected.ApplyStats()
+	victim := h.AddPlayer(geometry.Point{X: 200, Y: 300})
+	victim.Team = game.SurvivorsTeam
+	victim.Health = 10
+
+	h.Run(20, shootEveryOtherTick(infected.Id, victim.Position))
+	// the victim respawns with the infected stats
+	if victim.Team != game.InfectedTeam || victim.Stats.Speed != stats.Speed {
+		t.Fatalf("got team %d and speed %v, want the victim infected", victim.Team, victim.Stats.Speed)
+	}
+}
+
+func TestInfectedBulletsHaveShortRange(t *testing.T) {
+	h := newModeScene(game.ModeInfection)
+	stats := h.Game.TeamStats(game.InfectedTeam)
+
+	infected := h.AddPlayer(geometry.Point{X: 100, Y: 300})
+	infected.Team = game.InfectedTeam
+	infected.Stats = stats
+	infected.ApplyStats()
+	victim := h.AddPlayer(geometry.Point{X: 500, Y: 300})
+	victim.Team = game.SurvivorsTeam
+
+	h.Run(60, shootEveryOtherTick(infected.Id, victim.Position))
+	if victim.Health != config.SquareHealth() {
+		t.Fatalf("got health %d, want the far survivor untouched", victim.Health)
+	}
+}
+
+func TestSurvivorsWinOnTimeout(t *testing.T) {
+	h := newModeScene(game.ModeInfection)
+
+	h.AddPlayer(geometry.Point{X: 100, Y: 100}).Team = game.SurvivorsTeam
+	h.AddPlayer(geometry.Point{X: 600, Y: 600}).Team = game.InfectedTeam
+
+	// the round ends on the tick after the time is over
+	runFor(h, 3*time.Minute)
+	h.Step(nil)
+	assertMatchState(t, h, game.MatchEnded)
+	if winner := h.Game.MatchStatus().WinnerTeam; winner != game.SurvivorsTeam {
+		t.Fatalf("got the winner team %d, want the survivors", winner)
+	}
+}
+
+func TestInfectionRoundLastsMatchDuration(t *testing.T) {
+	h := newModeScene(game.ModeInfection, func(s *game.ServerSettings) {
+		s.MatchDuration = 5 * time.Minute
+	})
+
+	h.AddPlayer(geometry.Point{X: 100, Y: 100}).Team = game.SurvivorsTeam
+	h.AddPlayer(geometry.Point{X: 600, Y: 600}).Team = game.InfectedTeam
+
+	// the round starts after the warmup and the countdown
+	// and lasts the match's time instead of the infection's one
+	runFor(h, 21*time.Second)
+	assertMatchState(t, h, game.MatchLive)
+	runFor(h, 5*time.Minute-2*time.Second)
+	assertMatchState(t, h, game.MatchLive)
+	runFor(h, 2*time.Second)
+	assertMatchState(t, h, game.MatchEnded)
+}
@@ -0,0 +1,44 @@
+package harness
+
+import (
+	"online_shooter/internal/game/geometry"
+	"online_shooter/internal/model"
+	"slices"
+	"testing"
+)
+
+func TestKillGivesAssist(t *testing.T) {
+	h := newScene(false)
+	assistant := h.AddPlayer(geometry.Point{X: 100, Y: 300})
+	killer := h.AddPlayer(geometry.Point{X: 400, Y: 100})
+	victim := h.AddPlayer(geometry.Point{X: 400, Y: 300})
+	damage := assistant.Weapon.Damage
+	victim.Health = damage + damage/2
+
+	// the assistant hits first and the killer finishes the victim
+	aim := geometry.Point{X: 420, Y: 320}
+	var kills []model.KillEvent
+	for tick := 0; tick < 120; tick++ {
+		h.Step(map[int64]*model.PlayerUpdateMessage{
+			assistant.Id: {Shot: tick == 1, Aim: aim},
+			killer.Id:    {Shot: tick == 60, Aim: aim},
+		})
+		kills = append(kills, h.Game.KillEvents()...)
+	}
+
+	if len(kills) != 1 {
+		t.Fatalf("got %d kill events, want 1", len(kills))
+	}
+	kill := kills[0]
+	if kill.Killer != killer.Id || kill.Victim != victim.Id || !slices.Equal(kill.Assists, []int64{assistant.Id}) {
+		t.Fatalf("got the kill %+v, want the victim killed by %d with the assist of %d", kill, killer.Id, assistant.Id)
+	}
+	if assistant.Combat.Assists != 1 || killer.Combat.Assists != 0 || killer.Combat.Streak != 1 {
+		t.Fatalf("got %d and %d assists and streak %d, want the assist given only to the assistant",
+			assistant.Combat.Assists, killer.Combat.Assists, killer.Combat.Streak)
+	}
+	if assistant.Combat.DamageDealt != damage || killer.Combat.DamageDealt != damage/2 || victim.Combat.DamageTaken != damage+damage/2 {
+		t.Fatalf("got %d and %d dealt and %d taken, want the damage split between the shooters",
+			assistant.Combat.DamageDealt, killer.Combat.DamageDealt, victim.Combat.DamageTaken)
+	}
+}
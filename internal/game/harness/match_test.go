@@ -0,0 +1,97 @@
+package harness
+
+import (
+	"online_shooter/internal/game/arena"
+	"online_shooter/internal/game/game"
+	"testing"
+	"time"
+)
+
+// runFor updates the game till the game time has elapsed.
+func runFor(h *Harness, d time.Duration) {
+	h.Run(int((d+h.DeltaTime-1)/h.DeltaTime), nil)
+}
+
+// assertMatchState checks the state of the match.
+func assertMatchState(t *testing.T, h *Harness, want string) {
+	t.Helper()
+
+	if got := h.Game.MatchStatus().State; got != want {
+		t.Fatalf("got match state %q at tick %d, want %q", got, h.Tick, want)
+	}
+}
+
+func TestMatchRounds(t *testing.T) {
+	h := New(game.ServerSettings{
+		PlayerCount:   4,
+		ObstacleLevel: arena.LowObstaclesAmount,
+		Seed:          1,
+		MatchDuration: time.Minute,
+	})
+	assertMatchState(t, h, game.MatchWarmup)
+
+	// the kills during the warmup don't count
+	runFor(h, 15*time.Second)
+	assertMatchState(t, h, game.MatchCountdown)
+	for _, square := range h.Game.Squares {
+		square.Kills = 5
+	}
+
+	// the squares are frozen during the countdown
+	before := h.Snapshot()
+	runFor(h, 5*time.Second-time.Millisecond)
+	assertMatchState(t, h, game.MatchCountdown)
+	after := h.Snapshot()
+	after.Tick = before.Tick
+	if string(Dump(after)) != string(Dump(before)) {
+		t.Fatal("squares changed during the countdown")
+	}
+
+	// the round starts from scratch
+	runFor(h, time.Millisecond)
+	assertMatchState(t, h, game.MatchLive)
+	for _, square := range h.Game.Squares {
+		if square.Kills != 0 || square.Health != 100 {
+			t.Fatalf("got kills %d health %d, want a restarted square", square.Kills, square.Health)
+		}
+	}
+
+	// the round ends when its time is over and
+	// the next one starts after the intermission
+	runFor(h, time.Minute)
+	assertMatchState(t, h, game.MatchEnded)
+	runFor(h, 10*time.Second)
+	assertMatchState(t, h, game.MatchCountdown)
+}
+
+func TestMatchScoreLimit(t *testing.T) {
+	h := New(game.ServerSettings{
+		PlayerCount:   4,
+		ObstacleLevel: arena.LowObstaclesAmount,
+		Seed:          1,
+		ScoreLimit:    3,
+	})
+	runFor(h, 21*time.Second)
+	assertMatchState(t, h, game.MatchLive)
+
+	// the round without the time limit ends on the score limit
+	winner := h.Game.SortedSquares()[1]
+	winner.Kills = 3
+	h.Step(nil)
+	assertMatchState(t, h, game.MatchEnded)
+	if status := h.Game.MatchStatus(); status.Winner != winner.Id {
+		t.Fatalf("got winner %d, want %d", status.Winner, winner.Id)
+	}
+}
+
+func TestMatchWithoutLimits(t *testing.T) {
+	h := New(game.ServerSettings{
+		PlayerCount:   4,
+		ObstacleLevel: arena.LowObstaclesAmount,
+		Seed:          1,
+	})
+	assertMatchState(t, h, game.MatchLive)
+
+	runFor(h, 20*time.Minute)
+	assertMatchState(t, h, game.MatchLive)
+}
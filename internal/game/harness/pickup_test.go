@@ -0,0 +1,75 @@
+package harness
+
+import (
+	"online_shooter/internal/config"
+	"online_shooter/internal/game/entity"
+	"online_shooter/internal/game/geometry"
+	"testing"
+	"time"
+)
+
+func TestHealthPickupRespawns(t *testing.T) {
+	h := newScene(false)
+	pickup := h.AddPickup(entity.HealthPickup, geometry.Point{X: 120, Y: 120})
+
+	// the healthy square doesn't take the pack
+	player := h.AddPlayer(geometry.Point{X: 100, Y: 100})
+	h.Step(nil)
+	if !pickup.Active {
+		t.Fatal("healthy square took the health pack")
+	}
+
+	// the health is restored up to the max
+	player.Health = config.SquareHealth() - 10
+	h.Step(nil)
+	if pickup.Active || player.Health != config.SquareHealth() {
+		t.Fatalf("got health %d and active %v, want the full health and the taken pack", player.Health, pickup.Active)
+	}
+
+	// the pack appears again after the respawn period
+	player.Position = geometry.Point{X: 600, Y: 600}
+	runFor(h, 15*time.Second)
+	if !pickup.Active {
+		t.Fatal("health pack didn't respawn")
+	}
+}
+
+func TestAmmoPickupRefillsReserve(t *testing.T) {
+	h := newScene(false)
+	h.AddPickup(entity.AmmoPickup, geometry.Point{X: 120, Y: 120})
+
+	player := h.AddPlayer(geometry.Point{X: 100, Y: 100})
+	player.Ammo = entity.Ammo{Magazine: 1, Reserve: 0}
+	h.Step(nil)
+	if player.Ammo.Magazine != 1 || player.Ammo.Reserve != player.Weapon.Reserve {
+		t.Fatalf("got ammo %+v, want the full reserve", player.Ammo)
+	}
+}
+
+func TestSpeedPickupWearsOff(t *testing.T) {
+	h := newScene(false)
+	h.AddPickup(entity.SpeedPickup, geometry.Point{X: 120, Y: 120})
+
+	player := h.AddPlayer(geometry.Point{X: 100, Y: 100})
+	h.Step(nil)
+	if player.EffectiveSpeed() <= config.SquareSpeed() {
+		t.Fatalf("got speed %v, want the boosted speed", player.EffectiveSpeed())
+	}
+
+	runFor(h, 5*time.Second)
+	if player.EffectiveSpeed() != config.SquareSpeed() {
+		t.Fatalf("got speed %v, want the speed without the boost", player.EffectiveSpeed())
+	}
+}
+
+func TestBotGoesForHealth(t *testing.T) {
+	h := newScene(false)
+	pickup := h.AddPickup(entity.HealthPickup, geometry.Point{X: 600, Y: 300})
+
+	bot := h.AddBot(geometry.Point{X: 100, Y: 300})
+	bot.Health = 10
+	runFor(h, 3*time.Second)
+	if pickup.Active || bot.Health <= 10 {
+		t.Fatalf("got health %d and active %v, want the bot to take the pack", bot.Health, pickup.Active)
+	}
+}
@@ -0,0 +1,64 @@
+package harness
+
+import (
+	"github.com/chewxy/math32"
+	"online_shooter/internal/game/geometry"
+	"testing"
+	"time"
+)
+
+func TestKilledSquareWaitsForRespawn(t *testing.T) {
+	h := newScene(false)
+	shooter := h.AddPlayer(geometry.Point{X: 100, Y: 300})
+	victim := h.AddPlayer(geometry.Point{X: 400, Y: 300})
+
+	h.Run(180, shootEveryOtherTick(shooter.Id, geometry.Point{X: 420, Y: 320}))
+	if !victim.Dead || victim.Killer != shooter.Id {
+		t.Fatalf("got dead %v and killer %d, want the victim killed by %d", victim.Dead, victim.Killer, shooter.Id)
+	}
+
+	runFor(h, 3*time.Second)
+	if victim.Dead || victim.Vulnerable {
+		t.Fatalf("got dead %v and vulnerable %v, want the victim respawned with the protection", victim.Dead, victim.Vulnerable)
+	}
+	// the borders push the square inside the arena from the corner spawns
+	want := farthestSpawn(h, victim.Spawn, shooter.Position)
+	if !nearPoint(victim.Position, want, victim.Size) {
+		t.Fatalf("got the respawn at %v, want the farthest spawn %v", victim.Position, want)
+	}
+}
+
+func TestRespawnSkipsBlockedSpawn(t *testing.T) {
+	h := newScene(false)
+	shooter := h.AddPlayer(geometry.Point{X: 100, Y: 300})
+	victim := h.AddPlayer(geometry.Point{X: 400, Y: 300})
+
+	blocked := farthestSpawn(h, victim.Spawn, shooter.Position)
+	h.AddObstacle(blocked)
+
+	h.Run(180, shootEveryOtherTick(shooter.Id, geometry.Point{X: 420, Y: 320}))
+	runFor(h, 3*time.Second)
+	if victim.Dead || nearPoint(victim.Position, blocked, victim.Size) {
+		t.Fatalf("got dead %v at %v, want the victim respawned away from the blocked spawn", victim.Dead, victim.Position)
+	}
+}
+
+// farthestSpawn returns the spawn point farthest from the enemy.
+func farthestSpawn(h *Harness, own, enemy geometry.Point) geometry.Point {
+	best := own
+	bestDistance := geometry.GetDistanceBetweenTwoPoints(own, enemy)
+	for _, spawn := range h.Game.Arena.Spawns {
+		if distance := geometry.GetDistanceBetweenTwoPoints(spawn, enemy); distance > bestDistance {
+			best = spawn
+			bestDistance = distance
+		}
+	}
+
+	return best
+}
+
+// nearPoint checks if the position is less than
+// the size away from the point on both axes.
+func nearPoint(position, point geometry.Point, size float32) bool {
+	return math32.Abs(position.X-point.X) <= size && math32.Abs(position.Y-point.Y) <= size
+}
@@ -0,0 +1,84 @@
+package harness
+
+import (
+	"online_shooter/internal/game/game"
+	"online_shooter/internal/game/geometry"
+	"testing"
+	"time"
+)
+
+// fastSafeZone makes the safe zone of the battle royale
+// wait and shrink faster than by default.
+func fastSafeZone(s *game.ServerSettings) {
+	s.ZoneWait = 10 * time.Second
+	s.ZoneShrink = 5 * time.Second
+}
+
+func TestSafeZoneShrinks(t *testing.T) {
+	h := newModeScene(game.ModeBattleRoyale, fastSafeZone)
+	zone := h.Game.SafeZone
+	next, nextRadius := zone.NextCenter, zone.NextRadius
+
+	runFor(h, 10*time.Second)
+	if !zone.Shrinking {
+		t.Fatal("the zone hasn't started shrinking after the wait")
+	}
+
+	runFor(h, 5*time.Second)
+	if zone.Shrinking || zone.Center != next || zone.Radius != nextRadius {
+		t.Fatalf("got the zone at %v with radius %v, want the next circle at %v with radius %v",
+			zone.Center, zone.Radius, next, nextRadius)
+	}
+}
+
+func TestLastSquareStandingWins(t *testing.T) {
+	h := newModeScene(game.ModeBattleRoyale, fastSafeZone)
+	center := h.Game.SafeZone.Center
+	shooter := h.AddPlayer(center)
+	victim := h.AddPlayer(geometry.Point{X: center.X + 200, Y: center.Y})
+	victim.Health = 10
+	victim.Armor = 0
+
+	// the killed victim isn't respawned and the round ends
+	h.Run(30, shootEveryOtherTick(shooter.Id, victim.Position))
+	if !victim.Eliminated || victim.Position.X != center.X+200 {
+		t.Fatalf("got eliminated %v at %v, want the victim eliminated where it died", victim.Eliminated, victim.Position)
+	}
+	assertMatchState(t, h, game.MatchEnded)
+	if winner := h.Game.MatchStatus().Winner; winner != shooter.Id {
+		t.Fatalf("got the winner %d, want %d", winner, shooter.Id)
+	}
+
+	// the eliminated player watches the winner
+	if spectated := h.Game.SpectatedSquare(victim); spectated != shooter {
+		t.Fatalf("got the spectated square %v, want the shooter", spectated)
+	}
+}
+
+func TestOutsideSafeZoneDamage(t *testing.T) {
+	h := newModeScene(game.ModeBattleRoyale, fastSafeZone)
+	zone := h.Game.SafeZone
+	inside := h.AddPlayer(zone.Center)
+	outside := h.AddPlayer(zone.Center)
+
+	// the zone shrinks away from the square
+	runFor(h, 15*time.Second)
+	outside.Position = geometry.Point{X: zone.Center.X + zone.Radius + 100, Y: zone.Center.Y}
+	inside.Position = zone.Center
+	// the armor absorbs the zone's damage first
+	health := outside.Health + outside.Armor
+	runFor(h, time.Second)
+	if outside.Health+outside.Armor >= health || inside.Health+inside.Armor != health {
+		t.Fatalf("got the health and armor %d outside and %d inside, want only the square outside damaged",
+			outside.Health+outside.Armor, inside.Health+inside.Armor)
+	}
+}
+
+func TestRoyaleSquaresHaveArmor(t *testing.T) {
+	h := newModeScene(game.ModeBattleRoyale, fastSafeZone)
+	player := h.AddPlayer(h.Game.SafeZone.Center)
+
+	if player.Armor <= 0 || player.Armor != h.Game.Stats().Armor {
+		t.Fatalf("got armor %d, want the armor of the battle royale", player.Armor)
+	}
+}
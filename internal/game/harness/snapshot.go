@@ -0,0 +1,137 @@
+package harness
+
+import (
+	"encoding/json"
+	"github.com/chewxy/math32"
+	"online_shooter/internal/game/arena"
+	"online_shooter/internal/game/entity"
+	"online_shooter/internal/game/geometry"
+	"sort"
+)
+
+// precision is a factor the coordinates are rounded with
+// so tiny floating point differences don't break the snapshots
+const precision = 100
+
+// Snapshot is a state of the game at the tick.
+// Squares and obstacles are sorted by their ids.
+type Snapshot struct {
+	Tick      int             `json:"tick"`
+	Squares   []SquareState   `json:"squares"`
+	Obstacles []ObstacleState `json:"obstacles"`
+}
+
+type SquareState struct {
+	Id         int64          `json:"id"`
+	IsBot      bool           `json:"is_bot"`
+	Position   geometry.Point `json:"position"`
+	Health     int32          `json:"health"`
+	Armor      int32          `json:"armor,omitempty"`
+	Speed      float32        `json:"speed"`
+	Kills      uint16         `json:"kills"`
+	Deaths     uint16         `json:"deaths"`
+	Vulnerable bool           `json:"vulnerable"`
+	CanShoot   bool           `json:"can_shoot"`
+	Dead       bool           `json:"dead,omitempty"`
+	Bullets    []BulletState  `json:"bullets,omitempty"`
+}
+
+type BulletState struct {
+	Slot     int             `json:"slot"`
+	Position geometry.Point  `json:"position"`
+	Vector   geometry.Vector `json:"vector"`
+}
+
+type ObstacleState struct {
+	Id         int64          `json:"id"`
+	Position   geometry.Point `json:"position"`
+	Health     int32          `json:"health"`
+	Size       float32        `json:"size"`
+	Vulnerable bool           `json:"vulnerable"`
+}
+
+// Snapshot takes a snapshot of the current game state.
+//
+// Returns a pointer to the snapshot.
+func (h *Harness) Snapshot() *Snapshot {
+	return NewSnapshot(h.Tick, h.Game.Squares, h.Game.Arena.Obstacles)
+}
+
+// NewSnapshot takes a snapshot of the squares and the obstacles.
+//
+// Accepts the tick of the snapshot, the squares and the obstacles.
+//
+// Returns a pointer to the snapshot.
+func NewSnapshot(tick int, squares map[int64]*entity.Square, obstacles map[int64]*arena.Obstacle) *Snapshot {
+	snapshot := &Snapshot{
+		Tick:      tick,
+		Squares:   make([]SquareState, 0, len(squares)),
+		Obstacles: make([]ObstacleState, 0, len(obstacles)),
+	}
+
+	for _, s := range squares {
+		state := SquareState{
+			Id:         s.Id,
+			IsBot:      s.IsBot,
+			Position:   roundPoint(s.Position),
+			Health:     s.Health,
+			Armor:      s.Armor,
+			Speed:      round(s.EffectiveSpeed()),
+			Kills:      s.Kills,
+			Deaths:     s.Deaths,
+			Vulnerable: s.Vulnerable,
+			CanShoot:   s.CanShoot,
+			Dead:       s.Dead,
+		}
+		for i, b := range s.Bullets {
+			if b != nil {
+				state.Bullets = append(state.Bullets, BulletState{
+					Slot:     i,
+					Position: roundPoint(b.Position),
+					Vector:   geometry.Vector{X: round(b.Vector.X), Y: round(b.Vector.Y)},
+				})
+			}
+		}
+		snapshot.Squares = append(snapshot.Squares, state)
+	}
+
+	for _, o := range obstacles {
+		snapshot.Obstacles = append(snapshot.Obstacles, ObstacleState{
+			Id:         o.Id,
+			Position:   roundPoint(o.Position),
+			Health:     o.Health,
+			Size:       round(o.Size),
+			Vulnerable: o.Vulnerable,
+		})
+	}
+
+	sort.Slice(snapshot.Squares, func(i, j int) bool {
+		return snapshot.Squares[i].Id < snapshot.Squares[j].Id
+	})
+	sort.Slice(snapshot.Obstacles, func(i, j int) bool {
+		return snapshot.Obstacles[i].Id < snapshot.Obstacles[j].Id
+	})
+
+	return snapshot
+}
+
+// Dump encodes the snapshots to indented json
+// to be compared with the golden files.
+//
+// Accepts the snapshots.
+//
+// Returns the encoded snapshots.
+func Dump(snapshots ...*Snapshot) []byte {
+	data, _ := json.MarshalIndent(snapshots, "", "  ")
+	return append(data, '\n')
+}
+
+// round rounds the value with the snapshot precision.
+func round(value float32) float32 {
+	return math32.Round(value*precision) / precision
+}
+
+// roundPoint rounds the point's coordinates with the snapshot precision.
+func roundPoint(p geometry.Point) geometry.Point {
+	return geometry.Point{X: round(p.X), Y: round(p.Y)}
+}
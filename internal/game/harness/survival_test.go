@@ -0,0 +1,131 @@
+package harness
+
+import (
+	"online_shooter/internal/game/entity"
+	"online_shooter/internal/game/game"
+	"online_shooter/internal/game/geometry"
+	"testing"
+	"time"
+)
+
+// waveBots returns the bots of the current wave.
+func waveBots(h *Harness) []*entity.Square {
+	var bots []*entity.Square
+	for _, square := range h.Game.SortedSquares() {
+		if square.IsBot {
+			bots = append(bots, square)
+		}
+	}
+	return bots
+}
+
+func TestSurvivalWaves(t *testing.T) {
+	h := newModeScene(game.ModeSurvival)
+	player := h.Game.AddPlayer()
+	if player == nil || len(h.Game.Squares) != 1 || player.Team != game.SurvivorsTeam {
+		t.Fatalf("got %d squares, want only the player before the first wave", len(h.Game.Squares))
+	}
+
+	// the first wave comes after the break
+	runFor(h, 10*time.Second)
+	first := waveBots(h)
+	if status := h.Game.MatchStatus(); status.Wave != 1 || len(first) != 3 {
+		t.Fatalf("got wave %d with %d bots, want the first wave with 3 bots", status.Wave, len(first))
+	}
+
+	// the killed bots are despawned and the break starts
+	for _, bot := range first {
+		bot.Eliminate()
+	}
+	h.Step(nil)
+	if status := h.Game.MatchStatus(); len(waveBots(h)) != 0 || status.WaveBreak <= 0 {
+		t.Fatalf("got %d bots and the break %v, want the break after the wave", len(waveBots(h)), status.WaveBreak)
+	}
+
+	// the next wave is bigger and stronger
+	runFor(h, 10*time.Second)
+	second := waveBots(h)
+	fireRate := entity.NewWeapon(second[0].WeaponKind).FireRate
+	if len(second) != 5 || second[0].Health <= first[0].Health || second[0].Weapon.FireRate >= fireRate {
+		t.Fatalf("got %d bots with health %d and fire rate %v, want a stronger second wave",
+			len(second), second[0].Health, second[0].Weapon.FireRate)
+	}
+}
+
+func TestSurvivalSharedLives(t *testing.T) {
+	h := newModeScene(game.ModeSurvival)
+	player := h.Game.AddPlayer()
+
+	// the bots hunt the weak player which respawns while the lives are left
+	for i := 0; i < 120*60 && h.Game.MatchStatus().State != game.MatchEnded; i++ {
+		if player.Deaths == 1 && h.Game.MatchStatus().Lives != 9 {
+			t.Fatalf("got %d lives after the first death, want 9", h.Game.MatchStatus().Lives)
+		}
+		if player.Health > 1 {
+			player.Health = 1
+		}
+		h.Step(nil)
+	}
+
+	// the player is eliminated when the lives are over
+	status := h.Game.MatchStatus()
+	if status.State != game.MatchEnded || !player.Eliminated || status.WinnerTeam != game.WavesTeam {
+		t.Fatalf("got state %q eliminated %v winner %d, want the bots to win", status.State, player.Eliminated, status.WinnerTeam)
+	}
+	if player.Deaths != 11 {
+		t.Fatalf("got %d deaths, want the lives and the last death", player.Deaths)
+	}
+}
+
+func TestSurvivorTakesFreeSpawn(t *testing.T) {
+	h := newModeScene(game.ModeSurvival)
+	first := h.Game.AddPlayer()
+	second := h.Game.AddPlayer()
+
+	// the new player takes the spawn point of the one who has left
+	h.Game.RemovePlayer(first.Id)
+	third := h.Game.AddPlayer()
+	if third == nil {
+		t.Fatal("got no place for the new player")
+	}
+	if third.Spawn == second.Spawn || third.Spawn != first.Spawn {
+		t.Fatalf("got the spawn %v next to %v, want the free spawn %v", third.Spawn, second.Spawn, first.Spawn)
+	}
+}
+
+func TestWaveBotsDontOverlap(t *testing.T) {
+	h := newModeScene(game.ModeSurvival)
+	h.Game.AddPlayer()
+	h.AddObstacle(h.Game.Arena.TeamSpawns(game.WavesTeam)[0])
+
+	// the third wave has more bots than the spawn points,
+	// the bots are checked on the tick they are spawned
+	for h.Game.MatchStatus().Wave < 3 {
+		for _, bot := range waveBots(h) {
+			bot.Eliminate()
+		}
+		h.Step(nil)
+	}
+
+	bots := waveBots(h)
+	if len(bots) <= len(h.Game.Arena.TeamSpawns(game.WavesTeam)) {
+		t.Fatalf("got %d bots, want more bots than the spawn points", len(bots))
+	}
+	for i, bot := range bots {
+		for _, other := range bots[i+1:] {
+			if overlaps(bot.Position, bot.Size, other.Position, other.Size) {
+				t.Fatalf("got the bots at %v and %v, want them apart", bot.Position, other.Position)
+			}
+		}
+		for _, o := range h.Game.Arena.Obstacles {
+			if overlaps(bot.Position, bot.Size, o.Position, o.Size) {
+				t.Fatalf("got the bot at %v inside the obstacle at %v", bot.Position, o.Position)
+			}
+		}
+	}
+}
+
+// overlaps checks if two squares overlap.
+func overlaps(p1 geometry.Point, s1 float32, p2 geometry.Point, s2 float32) bool {
+	return p1.X < p2.X+s2 && p2.X < p1.X+s1 && p1.Y < p2.Y+s2 && p2.Y < p1.Y+s1
+}
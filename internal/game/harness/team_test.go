@@ -0,0 +1,102 @@
+package harness
+
+import (
+	"online_shooter/internal/game/arena"
+	"online_shooter/internal/game/game"
+	"online_shooter/internal/game/geometry"
+	"online_shooter/internal/model"
+	"testing"
+)
+
+func TestTeamsAreBalanced(t *testing.T) {
+	h := New(game.ServerSettings{
+		PlayerCount:   8,
+		ObstacleLevel: arena.LowObstaclesAmount,
+		Seed:          1,
+		Mode:          game.ModeTeamDeathmatch,
+	})
+
+	// the bots are split between the teams
+	// which spawn on the opposite sides
+	count := map[int]int{}
+	for _, square := range h.Game.Squares {
+		count[square.Team]++
+		if square.Team == 1 && square.Spawn.X != 0 || square.Team == 2 && square.Spawn.X != h.Game.Arena.Width {
+			t.Fatalf("got team %d spawn %v, want the team's side", square.Team, square.Spawn)
+		}
+	}
+	if count[1] != 4 || count[2] != 4 {
+		t.Fatalf("got teams %v, want 4 squares in each team", count)
+	}
+
+	// the players join different teams
+	first := h.Game.AddPlayer()
+	second := h.Game.AddPlayer()
+	if first.Team == second.Team {
+		t.Fatalf("both players joined team %d", first.Team)
+	}
+
+	// the bot replacing the player keeps the teams balanced
+	h.Game.RemovePlayer(first.Id)
+	count = map[int]int{}
+	for _, square := range h.Game.Squares {
+		count[square.Team]++
+	}
+	if count[1] != 4 || count[2] != 4 {
+		t.Fatalf("got teams %v after the player left, want 4 squares in each team", count)
+	}
+}
+
+func TestFriendlyFire(t *testing.T) {
+	for _, friendlyFire := range []bool{false, true} {
+		h := newModeScene(game.ModeTeamDeathmatch, func(s *game.ServerSettings) {
+			s.FriendlyFire = friendlyFire
+		})
+		shooter := h.AddPlayer(geometry.Point{X: 100, Y: 300})
+		teammate := h.AddPlayer(geometry.Point{X: 400, Y: 300})
+		shooter.Team = 1
+		teammate.Team = 1
+
+		script := func(tick int) map[int64]*model.PlayerUpdateMessage {
+			return map[int64]*model.PlayerUpdateMessage{
+				shooter.Id: {Shot: tick == 1, Aim: geometry.Point{X: 420, Y: 320}},
+			}
+		}
+		h.Run(40, script)
+
+		if damaged := teammate.Health < 100; damaged != friendlyFire {
+			t.Fatalf("got teammate health %d with friendly fire %v", teammate.Health, friendlyFire)
+		}
+	}
+}
+
+func TestTeamScore(t *testing.T) {
+	h := newModeScene(game.ModeTeamDeathmatch, func(s *game.ServerSettings) {
+		s.FriendlyFire = true
+	})
+	shooter := h.AddPlayer(geometry.Point{X: 100, Y: 300})
+	enemy := h.AddPlayer(geometry.Point{X: 400, Y: 300})
+	teammate := h.AddPlayer(geometry.Point{X: 100, Y: 500})
+	shooter.Team = 1
+	enemy.Team = 2
+	teammate.Team = 1
+	enemy.Health = 10
+	teammate.Health = 10
+
+	// kill the enemy and then the teammate when the weapon is ready again
+	aims := map[int]geometry.Point{1: {X: 420, Y: 320}, 40: {X: 120, Y: 520}}
+	script := func(tick int) map[int64]*model.PlayerUpdateMessage {
+		aim, ok := aims[tick]
+		return map[int64]*model.PlayerUpdateMessage{
+			shooter.Id: {Shot: ok, Aim: aim},
+		}
+	}
+	h.Run(60, script)
+
+	// killing the teammate doesn't score
+	scores := h.Game.MatchStatus().TeamScores
+	if len(scores) != 2 || scores[0] != 1 || scores[1] != 0 || shooter.Kills != 1 || teammate.Deaths != 1 {
+		t.Fatalf("got scores %v kills %d teammate deaths %d, want one kill of the enemy",
+			scores, shooter.Kills, teammate.Deaths)
+	}
+}
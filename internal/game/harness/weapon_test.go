@@ -0,0 +1,29 @@
+package harness
+
+import (
+	"online_shooter/internal/game/entity"
+	"online_shooter/internal/game/geometry"
+	"online_shooter/internal/model"
+	"testing"
+)
+
+func TestPlayerSwitchesWeapon(t *testing.T) {
+	h := newScene(false)
+	shooter := h.AddPlayer(geometry.Point{X: 100, Y: 300})
+	victim := h.AddPlayer(geometry.Point{X: 400, Y: 300})
+
+	// switch to the sniper and shoot when it is ready
+	script := func(tick int) map[int64]*model.PlayerUpdateMessage {
+		upd := &model.PlayerUpdateMessage{Weapon: int(entity.Sniper) + 1}
+		if tick > 0 {
+			upd = &model.PlayerUpdateMessage{Shot: true, Aim: victim.Position}
+		}
+		return map[int64]*model.PlayerUpdateMessage{shooter.Id: upd}
+	}
+	h.Run(120, script)
+
+	damage := entity.NewWeapon(entity.Sniper).Damage
+	if shooter.WeaponKind != entity.Sniper || victim.Health != 100-damage {
+		t.Fatalf("got weapon %s and victim health %d, want one sniper hit", shooter.Weapon.Name, victim.Health)
+	}
+}
@@ -0,0 +1,129 @@
+package spatial
+
+import (
+	"online_shooter/internal/game/geometry"
+)
+
+type entry[T any] struct {
+	item     T
+	position geometry.Point
+	size     float32
+}
+
+// Grid is a uniform grid which splits the arena into square cells.
+// Every item is stored in the cell containing its position,
+// so an area query checks only the cells near the area
+// instead of every item on the arena.
+type Grid[T any] struct {
+	cellSize float32
+	slack    float32
+	maxSize  float32
+	columns  int
+	rows     int
+	cells    [][]entry[T]
+}
+
+// NewGrid creates and initializes a new grid instance
+// covering the arena.
+//
+// Accepts the arena's sizes, a size of the grid's cell and a slack value
+// which is the distance the items can move after they were inserted.
+// Queries take the slack into account, so they still find the moved items.
+//
+// Returns a pointer to the created grid.
+func NewGrid[T any](width, height, cellSize, slack float32) *Grid[T] {
+	columns := int(width/cellSize) + 1
+	rows := int(height/cellSize) + 1
+
+	return &Grid[T]{
+		cellSize: cellSize,
+		slack:    slack,
+		columns:  columns,
+		rows:     rows,
+		cells:    make([][]entry[T], columns*rows),
+	}
+}
+
+// Clear removes every item from the grid
+// keeping the allocated memory for the next insertions.
+func (g *Grid[T]) Clear() {
+	for i := range g.cells {
+		g.cells[i] = g.cells[i][:0]
+	}
+	g.maxSize = 0
+}
+
+// Insert adds an item to the grid.
+//
+// Accepts the item, its position and size.
+func (g *Grid[T]) Insert(item T, position geometry.Point, size float32) {
+	x, y := g.cellOf(position.X, position.Y)
+	i := y*g.columns + x
+	g.cells[i] = append(g.cells[i], entry[T]{item: item, position: position, size: size})
+
+	// store the max size to find items
+	// which are stored in the neighbour cells
+	if size > g.maxSize {
+		g.maxSize = size
+	}
+}
+
+// Query finds the items which can intersect with the area.
+// The items are checked by their positions at the moment of insertion,
+// so callers must check the exact intersection themselves.
+// The items are returned in the same order for the same grid state.
+//
+// Accepts the area and a slice to append the found items to.
+//
+// Returns the slice with the found items.
+func (g *Grid[T]) Query(area geometry.Rect, found []T) []T {
+	// expand the area by the slack to find the moved items
+	expanded := geometry.Rect{
+		X:      area.X - g.slack,
+		Y:      area.Y - g.slack,
+		Width:  area.Width + 2*g.slack,
+		Height: area.Height + 2*g.slack,
+	}
+
+	// go through every cell covering the expanded area
+	// items are stored by their top left corner, so the cells
+	// on the top and the left are checked by the max item size
+	fromX, fromY := g.cellOf(expanded.X-g.maxSize, expanded.Y-g.maxSize)
+	toX, toY := g.cellOf(expanded.X+expanded.Width, expanded.Y+expanded.Height)
+	for y := fromY; y <= toY; y++ {
+		for x := fromX; x <= toX; x++ {
+			for _, e := range g.cells[y*g.columns+x] {
+				if expanded.Intersects(e.position, e.size) {
+					found = append(found, e.item)
+				}
+			}
+		}
+	}
+
+	return found
+}
+
+// cellOf counts the cell containing the point.
+// Points outside the arena belong to the border cells.
+//
+// Accepts the point's coords.
+//
+// Returns the cell's column and row.
+func (g *Grid[T]) cellOf(x, y float32) (int, int) {
+	return clamp(int(x/g.cellSize), g.columns), clamp(int(y/g.cellSize), g.rows)
+}
+
+// clamp limits the cell's index by the grid's sizes.
+//
+// Accepts the index and an amount of cells.
+//
+// Returns the limited index.
+func clamp(i, amount int) int {
+	if i < 0 {
+		return 0
+	}
+	if i >= amount {
+		return amount - 1
+	}
+	return i
+}
@@ -0,0 +1,183 @@
+package spatial
+
+import (
+	"fmt"
+	"math/rand"
+	"online_shooter/internal/game/geometry"
+	"testing"
+)
+
+const (
+	testSquareSize    = 40
+	testObstacleSize  = 60
+	testBulletSize    = 8
+	testBulletsAmount = 3
+	testScreenWidth   = 1280
+	testScreenHeight  = 720
+)
+
+type object struct {
+	position geometry.Point
+	size     float32
+}
+
+type world struct {
+	width     float32
+	height    float32
+	squares   []object
+	obstacles []object
+	bullets   []object
+}
+
+// newWorld creates a world with the same proportions as
+// an arena adapted to the squares amount.
+func newWorld(squaresAmount int) world {
+	rnd := rand.New(rand.NewSource(int64(squaresAmount)))
+
+	// adapt the arena like the arena package does
+	k := float32(squaresAmount) / 4
+	if k < 1 {
+		k = 1
+	}
+	width, height := testScreenWidth*k, testScreenHeight*k
+
+	randomObject := func(size float32) object {
+		return object{
+			position: geometry.Point{X: rnd.Float32() * width, Y: rnd.Float32() * height},
+			size:     size,
+		}
+	}
+
+	w := world{width: width, height: height}
+	for i := 0; i < squaresAmount; i++ {
+		w.squares = append(w.squares, randomObject(testSquareSize))
+		for j := 0; j < testBulletsAmount; j++ {
+			w.bullets = append(w.bullets, randomObject(testBulletSize))
+		}
+	}
+	for i := 0; i < 8*int(k); i++ {
+		w.obstacles = append(w.obstacles, randomObject(testObstacleSize))
+	}
+
+	return w
+}
+
+// naiveQuery scans every object like the collision checks
+// did before the grid was added.
+func naiveQuery(objects []object, area geometry.Rect, found []int) []int {
+	for i, o := range objects {
+		if area.Intersects(o.position, o.size) {
+			found = append(found, i)
+		}
+	}
+	return found
+}
+
+// newTestGrid creates a grid of the world filled with the objects.
+func newTestGrid(w world, objects []object) *Grid[int] {
+	g := NewGrid[int](w.width, w.height, 2*testObstacleSize, testSquareSize)
+	for i, o := range objects {
+		g.Insert(i, o.position, o.size)
+	}
+	return g
+}
+
+// areaOf creates an area which is covered by the object.
+func areaOf(o object) geometry.Rect {
+	return geometry.Rect{X: o.position.X, Y: o.position.Y, Width: o.size, Height: o.size}
+}
+
+func TestQueryFindsEveryIntersection(t *testing.T) {
+	w := newWorld(100)
+	g := newTestGrid(w, w.squares)
+
+	for _, b := range w.bullets {
+		area := areaOf(b)
+
+		// every object found by the naive scan must be found by the grid
+		found := make(map[int]bool)
+		for _, i := range g.Query(area, nil) {
+			found[i] = true
+		}
+		for _, i := range naiveQuery(w.squares, area, nil) {
+			if !found[i] {
+				t.Fatalf("square %d intersecting %v is not found", i, area)
+			}
+		}
+	}
+}
+
+func TestQueryFindsMovedItems(t *testing.T) {
+	g := NewGrid[int](1000, 1000, 100, 10)
+	g.Insert(1, geometry.Point{X: 95, Y: 95}, 4)
+
+	// the item has moved by less than the slack
+	found := g.Query(geometry.Rect{X: 104, Y: 104, Width: 1, Height: 1}, nil)
+	if len(found) != 1 {
+		t.Fatalf("expected the moved item to be found, got %v", found)
+	}
+
+	// the area is too far from the item
+	found = g.Query(geometry.Rect{X: 200, Y: 200, Width: 1, Height: 1}, nil)
+	if len(found) != 0 {
+		t.Fatalf("expected no items, got %v", found)
+	}
+}
+
+func TestClear(t *testing.T) {
+	g := NewGrid[int](1000, 1000, 100, 0)
+	g.Insert(1, geometry.Point{X: 10, Y: 10}, 4)
+	g.Clear()
+
+	if found := g.Query(geometry.Rect{Width: 100, Height: 100}, nil); len(found) != 0 {
+		t.Fatalf("expected the grid to be empty, got %v", found)
+	}
+}
+
+// benchmarkCollisions runs the collision queries of one tick:
+// every square and every bullet is checked against
+// the squares and the obstacles.
+func benchmarkCollisions(b *testing.B, squaresAmount int, useGrid bool) {
+	w := newWorld(squaresAmount)
+	movers := append(append([]object{}, w.squares...), w.bullets...)
+
+	var squaresGrid, obstaclesGrid *Grid[int]
+	if useGrid {
+		squaresGrid = NewGrid[int](w.width, w.height, 2*testObstacleSize, testSquareSize)
+		obstaclesGrid = newTestGrid(w, w.obstacles)
+	}
+
+	found := make([]int, 0, squaresAmount)
+	b.ResetTimer()
+	for n := 0; n < b.N; n++ {
+		if useGrid {
+			// the squares grid is rebuilt every tick
+			squaresGrid.Clear()
+			for i, s := range w.squares {
+				squaresGrid.Insert(i, s.position, s.size)
+			}
+		}
+
+		for _, m := range movers {
+			area := areaOf(m)
+			if useGrid {
+				found = squaresGrid.Query(area, found[:0])
+				found = obstaclesGrid.Query(area, found[:0])
+			} else {
+				found = naiveQuery(w.squares, area, found[:0])
+				found = naiveQuery(w.obstacles, area, found[:0])
+			}
+		}
+	}
+}
+
+func BenchmarkCollisions(b *testing.B) {
+	for _, squaresAmount := range []int{20, 50, 100} {
+		b.Run(fmt.Sprintf("naive/%d", squaresAmount), func(b *testing.B) {
+			benchmarkCollisions(b, squaresAmount, false)
+		})
+		b.Run(fmt.Sprintf("grid/%d", squaresAmount), func(b *testing.B) {
+			benchmarkCollisions(b, squaresAmount, true)
+		})
+	}
+}
@@ -0,0 +1,56 @@
+package timer
+
+import "time"
+
+// Timer counts down the game time. It is advanced
+// by the simulation step, so it stops while the game
+// is not updated and fires only inside the tick.
+// The zero value is a stopped timer.
+type Timer struct {
+	remaining time.Duration
+	active    bool
+}
+
+// Start starts the timer from the beginning.
+//
+// Accepts a duration of the timer.
+func (t *Timer) Start(d time.Duration) {
+	t.remaining = d
+	t.active = true
+}
+
+// Stop stops the timer without firing it.
+func (t *Timer) Stop() {
+	t.remaining = 0
+	t.active = false
+}
+
+// Advance moves the timer forward by the game time.
+//
+// Accepts the elapsed game time.
+//
+// Returns true if the timer has finished during this step.
+// A finished timer stops and doesn't fire again till it is restarted.
+func (t *Timer) Advance(dt time.Duration) bool {
+	if !t.active {
+		return false
+	}
+
+	t.remaining -= dt
+	if t.remaining > 0 {
+		return false
+	}
+
+	t.Stop()
+	return true
+}
+
+// Active reports whether the timer is counting down.
+func (t *Timer) Active() bool {
+	return t.active
+}
+
+// Remaining returns the game time left till the timer finishes.
+func (t *Timer) Remaining() time.Duration {
+	return t.remaining
+}
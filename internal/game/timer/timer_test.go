@@ -0,0 +1,55 @@
+package timer
+
+import (
+	"testing"
+	"time"
+)
+
+func TestTimerFiresOnce(t *testing.T) {
+	var timer Timer
+	timer.Start(100 * time.Millisecond)
+
+	if timer.Advance(60 * time.Millisecond) {
+		t.Fatal("timer fired too early")
+	}
+	if !timer.Active() || timer.Remaining() != 40*time.Millisecond {
+		t.Fatalf("got active %v remaining %v, want active with 40ms", timer.Active(), timer.Remaining())
+	}
+	if !timer.Advance(60 * time.Millisecond) {
+		t.Fatal("timer didn't fire")
+	}
+	if timer.Active() || timer.Advance(time.Second) {
+		t.Fatal("finished timer fired again")
+	}
+}
+
+func TestTimerZeroValueIsStopped(t *testing.T) {
+	var timer Timer
+	if timer.Active() || timer.Advance(time.Second) {
+		t.Fatal("zero timer is active")
+	}
+}
+
+func TestTimerStop(t *testing.T) {
+	var timer Timer
+	timer.Start(time.Second)
+	timer.Stop()
+
+	if timer.Advance(2 * time.Second) {
+		t.Fatal("stopped timer fired")
+	}
+}
+
+func TestTimerRestart(t *testing.T) {
+	var timer Timer
+	timer.Start(time.Second)
+	timer.Advance(900 * time.Millisecond)
+	timer.Start(time.Second)
+
+	if timer.Advance(900 * time.Millisecond) {
+		t.Fatal("restarted timer fired too early")
+	}
+	if !timer.Advance(100 * time.Millisecond) {
+		t.Fatal("restarted timer didn't fire")
+	}
+}
@@ -31,6 +31,10 @@ func (m *Menu) Draw(screen *ebiten.Image) {
 	// if it is a server connection module
 	case ServerConnectionMenuState:
 		m.drawConnectionSettingsMenu(screen, headerY)
+
+	// if it is a replay module
+	case ReplayMenuState:
+		m.drawReplayMenu(screen, headerY)
 	}
 }
 
@@ -47,6 +51,9 @@ func (m *Menu) drawMainMenu(screen *ebiten.Image, headerY int) {
 
 	// draw the "Start Server" button
 	drawButton(m.StartServerBtn, screen)
+
+	// draw the "Watch Replay" button
+	drawButton(m.WatchReplayBtn, screen)
 }
 
 // drawServerSettingsMenu draws the server settings menu module.
@@ -57,17 +64,60 @@ func (m *Menu) drawServerSettingsMenu(screen *ebiten.Image, headerY int) {
 	// draw the header
 	drawCenteredText(screen, "Server Settings", headerY, color.White)
 
-	// draw parameters
-	drawCenteredText(screen, fmt.Sprintf("Players Amount : %d", m.PlayerCount), headerY*2, color.White)
-	drawCenteredText(screen, fmt.Sprintf("Obstacles Amount: %s", m.ObstacleLevel), headerY*3, color.White)
-	drawCenteredText(screen, fmt.Sprintf("Is Server Public: %v", m.IsPublic), headerY*4, color.White)
+	// the settings are split into the columns short enough
+	// to fit above the "Start Server" button
+	columns := [][]string{
+		// the game parameters
+		{
+			fmt.Sprintf("Players Amount : %d", m.PlayerCount),
+			fmt.Sprintf("Obstacles Amount: %s", m.ObstacleLevel),
+			fmt.Sprintf("Game Mode: %s", m.Mode),
+			fmt.Sprintf("Teams: %d", m.Teams),
+			fmt.Sprintf("Friendly Fire: %v", m.FriendlyFire),
+			fmt.Sprintf("Dash Invulnerability: %v", m.DashInvulnerable),
+		},
+		// the match parameters
+		{
+			fmt.Sprintf("Match Time: %s", formatLimit(m.MatchDuration, m.MatchDuration.String())),
+			fmt.Sprintf("Score Limit: %s", formatLimit(m.ScoreLimit, fmt.Sprint(m.ScoreLimit))),
+			fmt.Sprintf("Zone Wait: %s", m.ZoneWait),
+			fmt.Sprintf("Zone Shrink: %s", m.ZoneShrink),
+			fmt.Sprintf("Respawn Delay: %s", formatRule(m.RespawnDelay, m.RespawnDelay.String())),
+		},
+		// the server parameters and the damage rules
+		{
+			fmt.Sprintf("Is Server Public: %v", m.IsPublic),
+			fmt.Sprintf("TLS Mode: %s", m.TLSMode),
+			fmt.Sprintf("Record Match: %v", m.Record),
+			fmt.Sprintf("Armor: %s", formatRule(m.Armor, fmt.Sprint(m.Armor))),
+			fmt.Sprintf("Regeneration: %s", formatRule(m.Regeneration, fmt.Sprintf("%d/s", m.Regeneration))),
+			fmt.Sprintf("Hit Slowdown: %s", formatRule(m.HitSlowdown, fmt.Sprintf("%.0f%%", m.HitSlowdown*100))),
+		},
+	}
+	for column, parameters := range columns {
+		for i, parameter := range parameters {
+			drawColumnText(screen, parameter, column, len(columns), headerY*2+i*headerY/2)
+		}
+	}
 
 	// draw the "Start Server" button
 	drawButton(m.StartServerBtn, screen)
 
-	// draw the hint
-	hintY := float32(screen.Bounds().Dy()) * 0.9
-	drawCenteredText(screen, "Use Arrow Keys and Space to Change Settings", int(hintY), color.White)
+	// draw the error of the previous start under the button
+	m.drawError(screen, headerY*7)
+
+	// draw the hint split into the lines fitting the screen
+	hints := []string{
+		"Arrow Keys: Players and Obstacles, G: Mode, N: Teams",
+		"F: Friendly Fire, D: Dash, M: Match Time, K: Score Limit",
+		"Z: Zone Wait, X: Zone Shrink, P: Respawn Delay, Space: Public",
+		"T: TLS, R: Record, A: Armor, H: Regeneration, S: Slowdown",
+	}
+	hintY := float32(screen.Bounds().Dy()) * 0.78
+	hintStep := float32(screen.Bounds().Dy()) / 25
+	for i, hint := range hints {
+		drawCenteredText(screen, hint, int(hintY+hintStep*float32(i)), color.White)
+	}
 }
 
 // drawConnectionSettingsMenu draws the connection settings menu module.
@@ -84,12 +134,74 @@ func (m *Menu) drawConnectionSettingsMenu(screen *ebiten.Image, headerY int) {
 	// draw port input
 	drawInputField(screen, &m.PortInput, "Server Port: ", headerY*3)
 
+	// draw secure connection flag
+	drawCenteredText(screen, fmt.Sprintf("Secure Connection: %v", m.Secure), headerY*4, color.White)
+
 	// draw the "Start Server" button
 	drawButton(m.ConnectToServerBtn, screen)
 
 	// draw the hint
 	hintY := float32(screen.Bounds().Dy()) * 0.9
-	drawCenteredText(screen, "Tab - switch field, Enter - confirm", int(hintY), color.White)
+	drawCenteredText(screen, "Tab - switch field, Up/Down - secure, Enter - confirm", int(hintY), color.White)
+}
+
+// drawReplayMenu draws the replay menu module.
+//
+// Accepts a pointer to the image object and a y
+// coordinate of the header as arguments.
+func (m *Menu) drawReplayMenu(screen *ebiten.Image, headerY int) {
+	// draw the header
+	drawCenteredText(screen, "Watch Replay", headerY, color.White)
+
+	// draw the replay file input
+	drawInputField(screen, &m.ReplayInput, "Replay File: ", headerY*2)
+
+	// draw the "Watch Replay" button
+	drawButton(m.WatchReplayBtn, screen)
+
+	// draw the hint
+	hintY := float32(screen.Bounds().Dy()) * 0.9
+	drawCenteredText(screen, "Type a path to the replay file and click Watch Replay", int(hintY), color.White)
+}
+
+// drawError draws the error of the last action if it exists.
+//
+// Accepts a pointer to the image object and a y coordinate of the error.
+func (m *Menu) drawError(screen *ebiten.Image, y int) {
+	if m.Error != "" {
+		drawCenteredText(screen, m.Error, y, color.RGBA{R: 255, G: 80, B: 80, A: 255})
+	}
+}
+
+// formatLimit formats the limit of the match
+// which is disabled if it is zero.
+//
+// Accepts the limit and its formatted value.
+//
+// Returns the formatted value or "off" if the limit is disabled.
+func formatLimit[T int | time.Duration](limit T, value string) string {
+	if limit <= 0 {
+		return "off"
+	}
+	return value
+}
+
+// formatRule formats the value of the damage or the respawn rule
+// which is the mode's one if it is zero
+// and is turned off if it is negative.
+//
+// Accepts the rule and its formatted value.
+//
+// Returns the formatted value, "mode" or "off".
+func formatRule[T int32 | float32 | time.Duration](rule T, value string) string {
+	switch {
+	case rule < 0:
+		return "off"
+	case rule == 0:
+		return "mode"
+	default:
+		return value
+	}
 }
 
 // drawCenteredText draws a text in the center of the screen.
@@ -113,6 +225,28 @@ func drawCenteredText(screen *ebiten.Image, s string, y int, clr color.Color) {
 	text.Draw(screen, s, assets.Font(), x, y+ascent, clr)
 }
 
+// drawColumnText draws a text in the center of
+// a column of the screen split into equal columns.
+//
+// Accepts a pointer to the screen, a string that needs to be printed,
+// an index of the column, zero for the left one,
+// an amount of the columns and a y coord of the text.
+func drawColumnText(screen *ebiten.Image, s string, column, columns int, y int) {
+	// count the center of the column
+	columnWidth := screen.Bounds().Dx() / columns
+	centerX := columnWidth*column + columnWidth/2
+
+	// count x coord
+	textWidthPx := font.MeasureString(assets.Font(), s).Ceil()
+	x := centerX - textWidthPx/2
+
+	// get text height
+	ascent := assets.Font().Metrics().Ascent.Ceil()
+
+	// draw text
+	text.Draw(screen, s, assets.Font(), x, y+ascent, color.White)
+}
+
 // drawInputField draws an input field on the screen.
 // Accepts a pointer to the image object, a pointer to the
 // text input object that needs to be drawn, its label and an y coord
@@ -3,6 +3,9 @@ package menu
 import (
 	"online_shooter/internal/config"
 	"online_shooter/internal/game/arena"
+	"online_shooter/internal/game/game"
+	"online_shooter/internal/replay"
+	"online_shooter/internal/tlsutil"
 	"time"
 )
 
@@ -10,22 +13,26 @@ const (
 	MainMenuState             = 0
 	ServerSettingsMenuState   = 1
 	ServerConnectionMenuState = 2
+	ReplayMenuState           = 3
 )
 
 type Menu struct {
 	State              int8
 	ConnectToServerBtn *Button
 	StartServerBtn     *Button
+	WatchReplayBtn     *Button
 	ConnectionSettings
-	ServerSettings
-	Active         bool
+	game.ServerSettings
+	ReplaySettings
+	Active bool
+	// Error is shown in the menu till the next action
+	Error          string
 	lastChangeTime time.Time
 }
 
-type ServerSettings struct {
-	PlayerCount   int
-	ObstacleLevel string
-	IsPublic      bool
+type ReplaySettings struct {
+	ReplayPath  string
+	ReplayInput TextInput
 }
 
 type ConnectionSettings struct {
@@ -33,6 +40,7 @@ type ConnectionSettings struct {
 	IpInput           TextInput
 	PortInput         TextInput
 	ActiveInputField  *TextInput
+	Secure            bool
 }
 
 // NewMenu creates and initializes
@@ -59,10 +67,23 @@ func NewMenu() *Menu {
 			Height: buttonHeight,
 			Label:  "Start Server",
 		},
-		ServerSettings: ServerSettings{
+		WatchReplayBtn: &Button{
+			X:      (config.ScreenWidth() - buttonWidth) / 2,
+			Y:      config.ScreenHeight()/2 + 3*buttonHeight,
+			Width:  buttonWidth,
+			Height: buttonHeight,
+			Label:  "Watch Replay",
+		},
+		ServerSettings: game.ServerSettings{
 			PlayerCount:   4,
 			ObstacleLevel: arena.MediumObstaclesAmount,
 			IsPublic:      true,
+			TLSMode:       tlsutil.ModeOff,
+			Seed:          config.GameSeed(),
+			Mode:          game.ModeDeathmatch,
+			Teams:         2,
+			ZoneWait:      30 * time.Second,
+			ZoneShrink:    20 * time.Second,
 		},
 		ConnectionSettings: ConnectionSettings{
 			IpInput: TextInput{
@@ -75,8 +96,25 @@ func NewMenu() *Menu {
 				MaxLength: 5,
 			},
 		},
+		ReplaySettings: ReplaySettings{
+			ReplayInput: TextInput{
+				// offer the latest recorded match
+				Value:    replay.Latest(config.RecordingsDir()),
+				IsActive: true,
+			},
+		},
 		Active: true,
 	}
+	m.ReplayInput.CursorPos = len(m.ReplayInput.Value)
 	m.ActiveInputField = &m.IpInput
 	return m
 }
+
+// ShowError opens the menu showing the error
+// of the action started from it.
+//
+// Accepts a message of the error.
+func (m *Menu) ShowError(message string) {
+	m.Error = message
+	m.Active = true
+}
@@ -5,11 +5,28 @@ import (
 	"github.com/hajimehoshi/ebiten/v2/inpututil"
 	"online_shooter/internal/event"
 	"online_shooter/internal/game/arena"
+	"online_shooter/internal/game/game"
+	"online_shooter/internal/tlsutil"
 	"time"
 )
 
 const waitTillChangeInMs = 150
 
+// options of the match limits, zero disables the limit,
+// and of the battle royale's phase timings
+var (
+	matchDurations = []time.Duration{0, 3 * time.Minute, 5 * time.Minute, 10 * time.Minute}
+	scoreLimits    = []int{0, 10, 20, 30}
+	teamsAmounts   = []int{2, 3, 4}
+	zoneWaits      = []time.Duration{15 * time.Second, 30 * time.Second, time.Minute}
+	zoneShrinks    = []time.Duration{10 * time.Second, 20 * time.Second, 40 * time.Second}
+	// the damage rules start with the mode's rules and the turned off ones
+	armors        = []int32{0, -1, 25, 50, 100}
+	regenerations = []int32{0, -1, 5, 10}
+	hitSlowdowns  = []float32{0, -1, 0.1, 0.2}
+	respawnDelays = []time.Duration{0, -1, time.Second, 5 * time.Second, 10 * time.Second}
+)
+
 // Update updates menu state in case it is active.
 //
 // Returns event that must be done after user's
@@ -40,6 +57,12 @@ func (m *Menu) readUserInteraction() event.Event {
 		// check if user interacts with mouse
 		// and keyboard to change connection address
 		return m.readConnectionMenuInteraction()
+
+	// if it is a replay menu state
+	case ReplayMenuState:
+		// check if user interacts with mouse
+		// and keyboard to choose the replay file
+		return m.readReplayMenuInteraction()
 	}
 
 	return -1
@@ -61,6 +84,11 @@ func (m *Menu) readMainMenuInteraction() {
 		if m.StartServerBtn.IsClicked(float32(x), float32(y)) {
 			m.State = ServerSettingsMenuState
 		}
+
+		// check Watch Replay button is clicked
+		if m.WatchReplayBtn.IsClicked(float32(x), float32(y)) {
+			m.State = ReplayMenuState
+		}
 		m.lastChangeTime = time.Now()
 	}
 }
@@ -125,6 +153,110 @@ func (m *Menu) readSettingMenuInteraction() event.Event {
 		m.lastChangeTime = now
 	}
 
+	// if t is pressed
+	if ebiten.IsKeyPressed(ebiten.KeyT) {
+		// switch server's tls mode
+		if m.TLSMode == tlsutil.ModeOff {
+			m.TLSMode = tlsutil.ModeSelfSigned
+		} else if m.TLSMode == tlsutil.ModeSelfSigned {
+			m.TLSMode = tlsutil.ModeCertificate
+		} else {
+			m.TLSMode = tlsutil.ModeOff
+		}
+		m.lastChangeTime = now
+	}
+
+	// if r is pressed
+	if ebiten.IsKeyPressed(ebiten.KeyR) {
+		// switch the match recording
+		m.Record = !m.Record
+		m.lastChangeTime = now
+	}
+
+	// if g is pressed
+	if ebiten.IsKeyPressed(ebiten.KeyG) {
+		// switch the game mode
+		m.Mode = nextOption(game.Modes, m.Mode)
+		m.lastChangeTime = now
+	}
+
+	// if n is pressed
+	if ebiten.IsKeyPressed(ebiten.KeyN) {
+		// switch the amount of the teams
+		m.Teams = nextOption(teamsAmounts, m.Teams)
+		m.lastChangeTime = now
+	}
+
+	// if f is pressed
+	if ebiten.IsKeyPressed(ebiten.KeyF) {
+		// switch the friendly fire
+		m.FriendlyFire = !m.FriendlyFire
+		m.lastChangeTime = now
+	}
+
+	// if d is pressed
+	if ebiten.IsKeyPressed(ebiten.KeyD) {
+		// switch the invulnerability of the dash
+		m.DashInvulnerable = !m.DashInvulnerable
+		m.lastChangeTime = now
+	}
+
+	// if m is pressed
+	if ebiten.IsKeyPressed(ebiten.KeyM) {
+		// switch the time limit of the round
+		m.MatchDuration = nextOption(matchDurations, m.MatchDuration)
+		m.lastChangeTime = now
+	}
+
+	// if k is pressed
+	if ebiten.IsKeyPressed(ebiten.KeyK) {
+		// switch the score limit of the round
+		m.ScoreLimit = nextOption(scoreLimits, m.ScoreLimit)
+		m.lastChangeTime = now
+	}
+
+	// if z is pressed
+	if ebiten.IsKeyPressed(ebiten.KeyZ) {
+		// switch the wait of the safe zone
+		m.ZoneWait = nextOption(zoneWaits, m.ZoneWait)
+		m.lastChangeTime = now
+	}
+
+	// if x is pressed
+	if ebiten.IsKeyPressed(ebiten.KeyX) {
+		// switch the shrinking time of the safe zone
+		m.ZoneShrink = nextOption(zoneShrinks, m.ZoneShrink)
+		m.lastChangeTime = now
+	}
+
+	// if a is pressed
+	if ebiten.IsKeyPressed(ebiten.KeyA) {
+		// switch the armor of the squares
+		m.Armor = nextOption(armors, m.Armor)
+		m.lastChangeTime = now
+	}
+
+	// if h is pressed
+	if ebiten.IsKeyPressed(ebiten.KeyH) {
+		// switch the regeneration of the health
+		m.Regeneration = nextOption(regenerations, m.Regeneration)
+		m.lastChangeTime = now
+	}
+
+	// if s is pressed
+	if ebiten.IsKeyPressed(ebiten.KeyS) {
+		// switch the slowdown of the hits
+		m.HitSlowdown = nextOption(hitSlowdowns, m.HitSlowdown)
+		m.lastChangeTime = now
+	}
+
+	// if p is pressed
+	if ebiten.IsKeyPressed(ebiten.KeyP) {
+		// switch the delay of the respawn
+		m.RespawnDelay = nextOption(respawnDelays, m.RespawnDelay)
+		m.lastChangeTime = now
+	}
+
 	// check if lbm is pressed
 	if ebiten.IsMouseButtonPressed(ebiten.MouseButtonLeft) {
 		x, y := ebiten.CursorPosition()
@@ -133,6 +265,7 @@ func (m *Menu) readSettingMenuInteraction() event.Event {
 		if m.StartServerBtn.IsClicked(float32(x), float32(y)) {
 			// close menu
 			m.Active = false
+			m.Error = ""
 
 			// start server
 			return event.EventStartServer
@@ -142,6 +275,19 @@ func (m *Menu) readSettingMenuInteraction() event.Event {
 	return -1
 }
 
+// nextOption returns the option following the current one.
+// The first option follows the last one and the unknown ones.
+//
+// Accepts the options and the current option.
+func nextOption[T comparable](options []T, current T) T {
+	for i, option := range options {
+		if option == current {
+			return options[(i+1)%len(options)]
+		}
+	}
+	return options[0]
+}
+
 // readConnectionMenuInteraction checks if user interacts
 // with a mouse and a keyboard to change the address
 // and click on the button in the connection menu.
@@ -166,35 +312,15 @@ func (m *Menu) readConnectionMenuInteraction() event.Event {
 		m.lastChangeTime = now
 	}
 
+	// secure connection switching logic
+	if inpututil.IsKeyJustPressed(ebiten.KeyUp) || inpututil.IsKeyJustPressed(ebiten.KeyDown) {
+		m.Secure = !m.Secure
+		m.lastChangeTime = now
+	}
+
 	// text input in the active field
 	if m.ActiveInputField != nil {
-		// add symbols
-		for _, r := range ebiten.AppendInputChars(nil) {
-			if m.ActiveInputField.MaxLength == 0 || len(m.ActiveInputField.Value) < m.ActiveInputField.MaxLength {
-				m.ActiveInputField.Value = m.ActiveInputField.Value[:m.ActiveInputField.CursorPos] + string(r) +
-					m.ActiveInputField.Value[m.ActiveInputField.CursorPos:]
-				m.ActiveInputField.CursorPos++
-				m.lastChangeTime = now
-			}
-		}
-
-		// delete symbol
-		if inpututil.IsKeyJustPressed(ebiten.KeyBackspace) && m.ActiveInputField.CursorPos > 0 {
-			m.ActiveInputField.Value = m.ActiveInputField.Value[:m.ActiveInputField.CursorPos-1] +
-				m.ActiveInputField.Value[m.ActiveInputField.CursorPos:]
-			m.ActiveInputField.CursorPos--
-			m.lastChangeTime = now
-		}
-
-		// move cursor
-		if inpututil.IsKeyJustPressed(ebiten.KeyLeft) && m.ActiveInputField.CursorPos > 0 {
-			m.ActiveInputField.CursorPos--
-			m.lastChangeTime = now
-		}
-		if inpututil.IsKeyJustPressed(ebiten.KeyRight) && m.ActiveInputField.CursorPos < len(m.ActiveInputField.Value) {
-			m.ActiveInputField.CursorPos++
-			m.lastChangeTime = now
-		}
+		m.readTextInput(m.ActiveInputField, now)
 	}
 
 	// check if the lbm is pressed
@@ -217,3 +343,67 @@ func (m *Menu) readConnectionMenuInteraction() event.Event {
 
 	return -1
 }
+
+// readReplayMenuInteraction checks if user interacts
+// with a mouse and a keyboard to change the replay file
+// and click on the button in the replay menu.
+func (m *Menu) readReplayMenuInteraction() event.Event {
+	// prevent too fast changing of the updates
+	now := time.Now()
+	if now.Sub(m.lastChangeTime) < waitTillChangeInMs*time.Millisecond {
+		return -1
+	}
+
+	// text input in the replay file field
+	m.readTextInput(&m.ReplayInput, now)
+
+	// check if the lbm is pressed
+	if ebiten.IsMouseButtonPressed(ebiten.MouseButtonLeft) {
+		// get click coords
+		clickX, clickY := ebiten.CursorPosition()
+
+		// check if the mouse covered a button during the click
+		if m.WatchReplayBtn.IsClicked(float32(clickX), float32(clickY)) {
+			// close menu
+			m.Active = false
+
+			// return watch replay event
+			m.ReplayPath = m.ReplayInput.Value
+			return event.EventWatchReplay
+		}
+	}
+
+	return -1
+}
+
+// readTextInput changes the value of the text input
+// using the typed symbols and the editing keys.
+//
+// Accepts a pointer to the text input and the current time.
+func (m *Menu) readTextInput(input *TextInput, now time.Time) {
+	// add symbols
+	for _, r := range ebiten.AppendInputChars(nil) {
+		if input.MaxLength == 0 || len(input.Value) < input.MaxLength {
+			input.Value = input.Value[:input.CursorPos] + string(r) + input.Value[input.CursorPos:]
+			input.CursorPos++
+			m.lastChangeTime = now
+		}
+	}
+
+	// delete symbol
+	if inpututil.IsKeyJustPressed(ebiten.KeyBackspace) && input.CursorPos > 0 {
+		input.Value = input.Value[:input.CursorPos-1] + input.Value[input.CursorPos:]
+		input.CursorPos--
+		m.lastChangeTime = now
+	}
+
+	// move cursor
+	if inpututil.IsKeyJustPressed(ebiten.KeyLeft) && input.CursorPos > 0 {
+		input.CursorPos--
+		m.lastChangeTime = now
+	}
+	if inpututil.IsKeyJustPressed(ebiten.KeyRight) && input.CursorPos < len(input.Value) {
+		input.CursorPos++
+		m.lastChangeTime = now
+	}
+}
@@ -6,6 +6,19 @@ import (
 )
 
 type GameUpdateMessage struct {
-	Obstacles map[int64]*arena.Obstacle `json:"obstacles"`
-	Squares   map[int64]*entity.Square  `json:"squares"`
+	Sequence   uint64                    `json:"sequence"`
+	Obstacles  map[int64]*arena.Obstacle `json:"obstacles"`
+	Squares    map[int64]*entity.Square  `json:"squares"`
+	Entered    []int64                   `json:"entered,omitempty"`
+	Left       []int64                   `json:"left,omitempty"`
+	Scoreboard []PlayerStatus            `json:"scoreboard,omitempty"`
+	Match      *MatchStatus              `json:"match,omitempty"`
+	Flags      []*entity.Flag            `json:"flags,omitempty"`
+	Zones      []*entity.Zone            `json:"zones,omitempty"`
+	SafeZone   *entity.SafeZone          `json:"safe_zone,omitempty"`
+	Pickups    []*entity.Pickup          `json:"pickups,omitempty"`
+	Kills      []KillEvent               `json:"kills,omitempty"`
+	// Spectating is an id of the square
+	// the eliminated player watches
+	Spectating int64 `json:"spectating,omitempty"`
 }
@@ -0,0 +1,16 @@
+package model
+
+import "online_shooter/internal/game/entity"
+
+type KillEvent struct {
+	// Killer is an id of the square which has killed
+	// the victim, zero if it has died not by a square
+	Killer int64 `json:"killer,omitempty"`
+	Victim int64 `json:"victim"`
+	// Assists are ids of the squares which have
+	// damaged the victim during the assist window
+	Assists []int64           `json:"assists,omitempty"`
+	Weapon  entity.WeaponKind `json:"weapon"`
+	// Streak is the killer's kill streak including the kill
+	Streak uint16 `json:"streak,omitempty"`
+}
@@ -0,0 +1,18 @@
+package model
+
+import "time"
+
+type MatchStatus struct {
+	Mode       string        `json:"mode"`
+	State      string        `json:"state"`
+	Remaining  time.Duration `json:"remaining"`
+	TeamScores []int         `json:"team_scores,omitempty"`
+	Winner     int64         `json:"winner,omitempty"`
+	WinnerTeam int           `json:"winner_team,omitempty"`
+	// Wave is the current wave of the survival, Lives
+	// are the players' shared lives left and WaveBreak
+	// is the time left till the next wave
+	Wave      int           `json:"wave,omitempty"`
+	Lives     int           `json:"lives,omitempty"`
+	WaveBreak time.Duration `json:"wave_break,omitempty"`
+}
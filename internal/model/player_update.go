@@ -9,4 +9,11 @@ type PlayerUpdateMessage struct {
 	DownKeyPressed  bool           `json:"down_key_pressed"`
 	Shot            bool           `json:"shot"`
 	Aim             geometry.Point `json:"aim"`
+	// Weapon is the slot of the weapon to switch to starting from one,
+	// zero keeps the current weapon
+	Weapon int `json:"weapon,omitempty"`
+	// Reload starts the reloading of the magazine
+	Reload bool `json:"reload,omitempty"`
+	// Dash makes the square dash in the direction of the movement
+	Dash bool `json:"dash,omitempty"`
 }
@@ -0,0 +1,27 @@
+package model
+
+import "image/color"
+
+type StatusResponse struct {
+	Seed    int64          `json:"seed"`
+	Players []PlayerStatus `json:"players"`
+	Match   *MatchStatus   `json:"match"`
+}
+
+type PlayerStatus struct {
+	Id     int64      `json:"id"`
+	IsBot  bool       `json:"is_bot"`
+	Team   int        `json:"team,omitempty"`
+	Kills  uint16     `json:"kills"`
+	Deaths uint16     `json:"deaths"`
+	Ping   uint16     `json:"ping"`
+	Color  color.RGBA `json:"color"`
+	// the stats of the square's fights in the round,
+	// Accuracy is the part of the fired bullets which have hit
+	Assists     uint16  `json:"assists"`
+	DamageDealt int32   `json:"damage_dealt"`
+	DamageTaken int32   `json:"damage_taken"`
+	Streak      uint16  `json:"streak"`
+	BestStreak  uint16  `json:"best_streak"`
+	Accuracy    float32 `json:"accuracy"`
+}
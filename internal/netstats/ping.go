@@ -0,0 +1,33 @@
+package netstats
+
+import (
+	"strconv"
+	"time"
+)
+
+// PingPayload creates a payload of the ping message
+// containing the time the ping is sent at.
+//
+// Accepts the current time.
+//
+// Returns the payload.
+func PingPayload(now time.Time) []byte {
+	return []byte(strconv.FormatInt(now.UnixNano(), 10))
+}
+
+// ParsePongPayload counts the round trip time using
+// the payload of the pong message which repeats
+// the payload of the ping message.
+//
+// Accepts the payload of the pong message and the current time.
+//
+// Returns the round trip time and an error if it exists,
+// otherwise nil.
+func ParsePongPayload(payload string, now time.Time) (time.Duration, error) {
+	sentAt, err := strconv.ParseInt(payload, 10, 64)
+	if err != nil {
+		return 0, err
+	}
+
+	return now.Sub(time.Unix(0, sentAt)), nil
+}
@@ -0,0 +1,176 @@
+package netstats
+
+import (
+	"github.com/chewxy/math32"
+	"sync"
+	"time"
+)
+
+const (
+	// HistorySize is an amount of the stored snapshot intervals
+	HistorySize = 60
+
+	// smoothing is a weight of a new sample in the moving averages
+	smoothing = 0.1
+
+	// lateFactor shows how many times a snapshot interval must exceed
+	// the average one to count the snapshot as a late one
+	lateFactor = 2
+)
+
+type Stats struct {
+	mutex sync.Mutex
+
+	ping   time.Duration
+	jitter float32
+
+	lastSequence    uint64
+	lastSnapshot    time.Time
+	averageInterval float32
+	dropped         uint32
+	late            uint32
+	intervals       [HistorySize]float32
+	intervalIndex   int
+
+	windowStart        time.Time
+	windowSnapshots    uint32
+	windowBytesUp      uint64
+	windowBytesDown    uint64
+	snapshotRate       uint32
+	bytesUpPerSecond   uint64
+	bytesDownPerSecond uint64
+}
+
+type Summary struct {
+	Ping               time.Duration
+	Jitter             time.Duration
+	SnapshotRate       uint32
+	BytesUpPerSecond   uint64
+	BytesDownPerSecond uint64
+	Dropped            uint32
+	Late               uint32
+	Intervals          []float32
+}
+
+// Pong registers a new round trip time sample.
+// The jitter is counted as a smoothed difference
+// between the consecutive samples.
+//
+// Accepts the round trip time.
+func (s *Stats) Pong(rtt time.Duration) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	// update jitter if there is a previous sample
+	if s.ping > 0 {
+		diff := math32.Abs(float32(rtt - s.ping))
+		s.jitter += (diff - s.jitter) * smoothing
+	}
+
+	s.ping = rtt
+}
+
+// Sent registers a message sent to the server.
+//
+// Accepts a size of the message in bytes.
+func (s *Stats) Sent(size int) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	s.roll(time.Now())
+	s.windowBytesUp += uint64(size)
+}
+
+// Received registers a snapshot received from the server.
+// Gaps in the snapshot sequence are counted as dropped snapshots
+// and snapshots coming much later than usual are counted as late.
+//
+// Accepts a size of the message in bytes and
+// the snapshot's sequence number.
+func (s *Stats) Received(size int, sequence uint64) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	now := time.Now()
+	s.roll(now)
+	s.windowBytesDown += uint64(size)
+	s.windowSnapshots++
+
+	// check the sequence
+	if s.lastSequence != 0 && sequence > s.lastSequence+1 {
+		s.dropped += uint32(sequence - s.lastSequence - 1)
+	}
+	if sequence > s.lastSequence {
+		s.lastSequence = sequence
+	}
+
+	// check the interval between snapshots
+	if !s.lastSnapshot.IsZero() {
+		interval := float32(now.Sub(s.lastSnapshot).Seconds() * 1000)
+		if s.averageInterval > 0 && interval > s.averageInterval*lateFactor {
+			s.late++
+		}
+		if s.averageInterval == 0 {
+			s.averageInterval = interval
+		}
+		s.averageInterval += (interval - s.averageInterval) * smoothing
+
+		// store the interval in the history
+		s.intervals[s.intervalIndex] = interval
+		s.intervalIndex = (s.intervalIndex + 1) % HistorySize
+	}
+	s.lastSnapshot = now
+}
+
+// Summary returns the current network statistics.
+func (s *Stats) Summary() Summary {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	s.roll(time.Now())
+
+	// order the intervals from the oldest to the newest
+	intervals := make([]float32, 0, HistorySize)
+	intervals = append(intervals, s.intervals[s.intervalIndex:]...)
+	intervals = append(intervals, s.intervals[:s.intervalIndex]...)
+
+	return Summary{
+		Ping:               s.ping,
+		Jitter:             time.Duration(s.jitter),
+		SnapshotRate:       s.snapshotRate,
+		BytesUpPerSecond:   s.bytesUpPerSecond,
+		BytesDownPerSecond: s.bytesDownPerSecond,
+		Dropped:            s.dropped,
+		Late:               s.late,
+		Intervals:          intervals,
+	}
+}
+
+// roll closes the current one second window
+// and stores its rates if the window is over.
+//
+// Accepts the current time.
+func (s *Stats) roll(now time.Time) {
+	if s.windowStart.IsZero() {
+		s.windowStart = now
+		return
+	}
+
+	// check if the window is over
+	elapsed := now.Sub(s.windowStart)
+	if elapsed < time.Second {
+		return
+	}
+
+	// store the rates
+	seconds := elapsed.Seconds()
+	s.snapshotRate = uint32(float64(s.windowSnapshots) / seconds)
+	s.bytesUpPerSecond = uint64(float64(s.windowBytesUp) / seconds)
+	s.bytesDownPerSecond = uint64(float64(s.windowBytesDown) / seconds)
+
+	// start a new window
+	s.windowStart = now
+	s.windowSnapshots = 0
+	s.windowBytesUp = 0
+	s.windowBytesDown = 0
+}
@@ -0,0 +1,67 @@
+package replay
+
+import (
+	"online_shooter/internal/game/arena"
+	"online_shooter/internal/game/entity"
+	"online_shooter/internal/game/game"
+	"online_shooter/internal/model"
+	"time"
+)
+
+// Version is a version of the replay file format.
+const Version = 2
+
+// FileExtension is an extension of the replay files.
+const FileExtension = ".replay.gz"
+
+const (
+	EventJoin  = "join"
+	EventLeave = "leave"
+)
+
+// Header is the first record of the replay file
+// describing the recorded match.
+type Header struct {
+	Version  int                 `json:"version"`
+	Seed     int64               `json:"seed"`
+	Settings game.ServerSettings `json:"settings"`
+	// Config contains the game variables of the server
+	Config    map[string]string `json:"config"`
+	Arena     *arena.Arena      `json:"arena"`
+	StartedAt time.Time         `json:"started_at"`
+}
+
+// Frame is a state of the match after the tick.
+// Frames are not recorded every tick and the file contains
+// only the squares and the obstacles which have changed
+// since the previous frame and the ids of the removed squares.
+// The inputs and the events of the ticks between
+// the frames are stored in the next frame.
+type Frame struct {
+	Sequence uint64 `json:"sequence"`
+	// Tick is an amount of the ticks simulated before the frame
+	Tick      uint64                    `json:"tick"`
+	Time      time.Duration             `json:"time"`
+	Squares   map[int64]*entity.Square  `json:"squares,omitempty"`
+	Removed   []int64                   `json:"removed,omitempty"`
+	Obstacles map[int64]*arena.Obstacle `json:"obstacles,omitempty"`
+	Inputs    []Input                   `json:"inputs,omitempty"`
+	Events    []Event                   `json:"events,omitempty"`
+}
+
+// Input is an update message received from the player
+// after the tick. The last input of the player
+// received after the tick is applied in the next one.
+type Input struct {
+	Tick   uint64                    `json:"tick"`
+	Player int64                     `json:"player"`
+	Update model.PlayerUpdateMessage `json:"update"`
+}
+
+// Event is something happened in the match
+// after the tick.
+type Event struct {
+	Tick   uint64 `json:"tick"`
+	Type   string `json:"type"`
+	Player int64  `json:"player"`
+}
@@ -0,0 +1,66 @@
+package replay
+
+import "time"
+
+const (
+	MinSpeed = 0.25
+	MaxSpeed = 4
+)
+
+// Player plays the replay back. It is advanced
+// by the real time multiplied by the playback speed.
+type Player struct {
+	Replay   *Replay
+	Position time.Duration
+	Speed    float64
+	Paused   bool
+}
+
+// NewPlayer creates a player for the replay.
+//
+// Accepts a pointer to the replay.
+//
+// Returns a pointer to the created player.
+func NewPlayer(r *Replay) *Player {
+	return &Player{
+		Replay:   r,
+		Position: r.Frames[0].Time,
+		Speed:    1,
+	}
+}
+
+// Advance moves the playback forward.
+// The playback is paused at the end of the replay.
+//
+// Accepts the elapsed real time.
+func (p *Player) Advance(dt time.Duration) {
+	if p.Paused {
+		return
+	}
+
+	p.Seek(p.Position + time.Duration(float64(dt)*p.Speed))
+	if p.Position == p.Replay.Duration() {
+		p.Paused = true
+	}
+}
+
+// Seek moves the playback to the time
+// limited by the replay duration.
+//
+// Accepts the time from the start of the match.
+func (p *Player) Seek(t time.Duration) {
+	p.Position = max(p.Replay.Frames[0].Time, min(t, p.Replay.Duration()))
+}
+
+// SetSpeed changes the playback speed
+// limited by the min and max speed.
+//
+// Accepts the new speed.
+func (p *Player) SetSpeed(speed float64) {
+	p.Speed = max(MinSpeed, min(speed, MaxSpeed))
+}
+
+// Frame returns the frame at the playback position.
+func (p *Player) Frame() *Frame {
+	return p.Replay.FrameAt(p.Position)
+}
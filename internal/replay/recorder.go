@@ -0,0 +1,93 @@
+package replay
+
+import (
+	"bufio"
+	"compress/gzip"
+	"encoding/json"
+	"os"
+	"path/filepath"
+)
+
+// flushInterval is an amount of frames between
+// flushing the recorded data to the file, so the
+// replay can be watched even if the server is killed
+const flushInterval = 60
+
+// Recorder writes the match to the replay file.
+// The file is a gzip compressed stream of json records:
+// the header followed by the frames.
+type Recorder struct {
+	file    *os.File
+	buffer  *bufio.Writer
+	gzip    *gzip.Writer
+	encoder *json.Encoder
+	frames  int
+}
+
+// NewRecorder creates the replay file and writes its header.
+//
+// Accepts a path to the file and a pointer to the header.
+//
+// Returns a pointer to the created recorder and an error if it exists.
+func NewRecorder(path string, header *Header) (*Recorder, error) {
+	// create the directory of the file
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return nil, err
+	}
+
+	file, err := os.Create(path)
+	if err != nil {
+		return nil, err
+	}
+
+	r := &Recorder{file: file}
+	r.buffer = bufio.NewWriter(file)
+	r.gzip = gzip.NewWriter(r.buffer)
+	r.encoder = json.NewEncoder(r.gzip)
+
+	// write the header
+	header.Version = Version
+	if err = r.encoder.Encode(header); err != nil {
+		file.Close()
+		return nil, err
+	}
+
+	return r, nil
+}
+
+// WriteFrame writes the frame to the replay file.
+//
+// Accepts a pointer to the frame.
+//
+// Returns an error if it exists.
+func (r *Recorder) WriteFrame(frame *Frame) error {
+	if err := r.encoder.Encode(frame); err != nil {
+		return err
+	}
+
+	// flush the data to the file from time to time
+	r.frames++
+	if r.frames%flushInterval == 0 {
+		if err := r.gzip.Flush(); err != nil {
+			return err
+		}
+		return r.buffer.Flush()
+	}
+
+	return nil
+}
+
+// Close finishes the replay file.
+//
+// Returns an error if it exists.
+func (r *Recorder) Close() error {
+	if err := r.gzip.Close(); err != nil {
+		r.file.Close()
+		return err
+	}
+	if err := r.buffer.Flush(); err != nil {
+		r.file.Close()
+		return err
+	}
+	return r.file.Close()
+}
@@ -0,0 +1,224 @@
+package replay
+
+import (
+	"bufio"
+	"compress/gzip"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"online_shooter/internal/game/entity"
+	"online_shooter/internal/game/geometry"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Replay is a recorded match loaded into memory.
+// Every frame has all the squares and the obstacles:
+// the ones missed in the file are taken from the previous frame.
+type Replay struct {
+	Header *Header
+	Frames []*Frame
+}
+
+// Load reads the replay file. A file which is not
+// finished, for example because the server is still
+// running, is read till the last complete frame.
+//
+// Accepts a path to the file.
+//
+// Returns a pointer to the loaded replay and an error if it exists.
+func Load(path string) (*Replay, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	reader, err := gzip.NewReader(bufio.NewReader(file))
+	if err != nil {
+		return nil, err
+	}
+	decoder := json.NewDecoder(reader)
+
+	// read the header
+	r := &Replay{Header: &Header{}}
+	if err = decoder.Decode(r.Header); err != nil {
+		return nil, fmt.Errorf("failed to read the replay header: %w", err)
+	}
+	if r.Header.Version != Version {
+		return nil, fmt.Errorf("unsupported replay version %d", r.Header.Version)
+	}
+
+	// read the frames
+	squares := make(map[int64]*entity.Square)
+	obstacles := r.Header.Arena.Obstacles
+	for {
+		frame := &Frame{}
+		err = decoder.Decode(frame)
+		if err == io.EOF || errors.Is(err, io.ErrUnexpectedEOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to read the replay frame %d: %w", len(r.Frames), err)
+		}
+
+		// take the entities from the previous frame if they haven't changed
+		squares = applyChanges(squares, frame.Squares, frame.Removed)
+		frame.Squares = squares
+		obstacles = applyChanges(obstacles, frame.Obstacles, nil)
+		frame.Obstacles = obstacles
+
+		r.Frames = append(r.Frames, frame)
+	}
+
+	if len(r.Frames) == 0 {
+		return nil, errors.New("the replay has no frames")
+	}
+
+	return r, nil
+}
+
+// Duration returns the duration of the recorded match.
+func (r *Replay) Duration() time.Duration {
+	return r.Frames[len(r.Frames)-1].Time
+}
+
+// FrameAt finds the frame at the time. The squares of the frames
+// recorded around the time are interpolated.
+//
+// Accepts the time from the start of the match.
+//
+// Returns a pointer to the frame.
+func (r *Replay) FrameAt(t time.Duration) *Frame {
+	// find the first frame after the time
+	i := sort.Search(len(r.Frames), func(i int) bool {
+		return r.Frames[i].Time > t
+	})
+	if i == 0 {
+		return r.Frames[0]
+	}
+	if i == len(r.Frames) {
+		return r.Frames[i-1]
+	}
+
+	return interpolate(r.Frames[i-1], r.Frames[i], t)
+}
+
+// interpolate creates a frame between the two frames
+// moving the squares and their bullets between the positions
+// they have in the frames. The squares which have respawned
+// or shot a new bullet in the meantime are not moved.
+//
+// Accepts pointers to the previous and the next frames
+// and the time between them.
+//
+// Returns a pointer to the created frame.
+func interpolate(previous, next *Frame, t time.Duration) *Frame {
+	ratio := float32(t-previous.Time) / float32(next.Time-previous.Time)
+
+	frame := *previous
+	frame.Squares = make(map[int64]*entity.Square, len(previous.Squares))
+	for id, s := range previous.Squares {
+		target := next.Squares[id]
+		if target == nil || target.Deaths != s.Deaths {
+			frame.Squares[id] = s
+			continue
+		}
+
+		square := s.Clone()
+		square.Position = lerp(s.Position, target.Position, ratio)
+		for slot, b := range square.Bullets {
+			// the slots change when the square switches the weapon
+			if slot >= len(target.Bullets) {
+				break
+			}
+			if b != nil && target.Bullets[slot] != nil && target.Bullets[slot].Vector == b.Vector {
+				b.Position = lerp(b.Position, target.Bullets[slot].Position, ratio)
+			}
+		}
+		frame.Squares[id] = square
+	}
+
+	return &frame
+}
+
+// lerp counts the point between the two points.
+//
+// Accepts the points and the ratio of the distance between them.
+//
+// Returns the counted point.
+func lerp(from, to geometry.Point, ratio float32) geometry.Point {
+	return geometry.Point{
+		X: from.X + (to.X-from.X)*ratio,
+		Y: from.Y + (to.Y-from.Y)*ratio,
+	}
+}
+
+// applyChanges creates a map of the entities with the changed
+// entities of the frame taking the rest from the previous frame.
+//
+// Accepts the entities of the previous frame, the changed
+// entities and ids of the removed entities.
+//
+// Returns the entities of the frame.
+func applyChanges[T any](previous, changed map[int64]*T, removed []int64) map[int64]*T {
+	if len(changed) == 0 && len(removed) == 0 {
+		return previous
+	}
+
+	entities := make(map[int64]*T, len(previous)+len(changed))
+	for id, e := range previous {
+		entities[id] = e
+	}
+	for id, e := range changed {
+		entities[id] = e
+	}
+	for _, id := range removed {
+		delete(entities, id)
+	}
+
+	return entities
+}
+
+// Latest finds the most recent replay file in the directory.
+//
+// Accepts a path to the directory.
+//
+// Returns a path to the file or an empty string if there are no replays.
+func Latest(dir string) string {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return ""
+	}
+
+	var latest string
+	var latestTime time.Time
+	for _, entry := range entries {
+		if entry.IsDir() || !strings.HasSuffix(entry.Name(), FileExtension) {
+			continue
+		}
+		info, err := entry.Info()
+		if err != nil {
+			continue
+		}
+		if latest == "" || info.ModTime().After(latestTime) {
+			latest = filepath.Join(dir, entry.Name())
+			latestTime = info.ModTime()
+		}
+	}
+
+	return latest
+}
+
+// FileName creates a name of the replay file for the match.
+//
+// Accepts the start time and the seed of the match.
+//
+// Returns the name of the file.
+func FileName(startedAt time.Time, seed int64) string {
+	return fmt.Sprintf("match_%s_%d%s", startedAt.Format("20060102_150405"), seed, FileExtension)
+}
@@ -0,0 +1,251 @@
+package replay
+
+import (
+	"compress/gzip"
+	"online_shooter/internal/game/arena"
+	"online_shooter/internal/game/entity"
+	"online_shooter/internal/game/geometry"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// writeReplay records the frames to a new file.
+//
+// Returns a path to the file.
+func writeReplay(t *testing.T, frames []*Frame, finish bool) string {
+	t.Helper()
+
+	path := filepath.Join(t.TempDir(), FileName(time.Unix(0, 0), 1))
+	header := &Header{
+		Seed: 1,
+		Arena: &arena.Arena{
+			Width:     100,
+			Height:    100,
+			Obstacles: map[int64]*arena.Obstacle{1: {Id: 1, Health: 100}},
+		},
+	}
+
+	r, err := NewRecorder(path, header)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, frame := range frames {
+		if err = r.WriteFrame(frame); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if finish {
+		if err = r.Close(); err != nil {
+			t.Fatal(err)
+		}
+	} else {
+		// flush the data like the running server does
+		// and leave the file unfinished
+		if err = r.gzip.Flush(); err != nil {
+			t.Fatal(err)
+		}
+		if err = r.buffer.Flush(); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	return path
+}
+
+// newFrames creates frames with a moving square
+// where the obstacle is damaged in the second frame.
+func newFrames(amount int) []*Frame {
+	frames := make([]*Frame, amount)
+	for i := range frames {
+		frames[i] = &Frame{
+			Sequence: uint64(i),
+			Time:     time.Duration(i) * 100 * time.Millisecond,
+			Squares: map[int64]*entity.Square{
+				7: {Id: 7, Position: geometry.Point{X: float32(i)}},
+			},
+		}
+	}
+	if amount > 1 {
+		frames[1].Obstacles = map[int64]*arena.Obstacle{1: {Id: 1, Health: 80}}
+		frames[1].Inputs = []Input{{Player: 7}}
+		frames[1].Events = []Event{{Type: EventJoin, Player: 7}}
+	}
+	return frames
+}
+
+func TestRecordAndLoad(t *testing.T) {
+	r, err := Load(writeReplay(t, newFrames(5), true))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if r.Header.Seed != 1 || r.Header.Version != Version {
+		t.Fatalf("got header %+v", r.Header)
+	}
+	if len(r.Frames) != 5 {
+		t.Fatalf("got %d frames, want 5", len(r.Frames))
+	}
+	if r.Frames[3].Squares[7].Position.X != 3 {
+		t.Fatalf("got square position %v, want 3", r.Frames[3].Squares[7].Position.X)
+	}
+	if len(r.Frames[1].Inputs) != 1 || len(r.Frames[1].Events) != 1 {
+		t.Fatal("inputs and events are not recorded")
+	}
+
+	// the obstacles are taken from the previous frames
+	if r.Frames[0].Obstacles[1].Health != 100 {
+		t.Fatalf("got obstacle health %d in the first frame, want 100", r.Frames[0].Obstacles[1].Health)
+	}
+	if r.Frames[4].Obstacles[1].Health != 80 {
+		t.Fatalf("got obstacle health %d in the last frame, want 80", r.Frames[4].Obstacles[1].Health)
+	}
+}
+
+func TestLoadChangedSquares(t *testing.T) {
+	frames := newFrames(3)
+	// the square 7 doesn't move in the second frame
+	// and is replaced with the square 8 in the third one
+	frames[1].Squares = map[int64]*entity.Square{8: {Id: 8}}
+	frames[2].Squares = nil
+	frames[2].Removed = []int64{7}
+
+	r, err := Load(writeReplay(t, frames, true))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(r.Frames[1].Squares) != 2 || r.Frames[1].Squares[7].Position.X != 0 {
+		t.Fatalf("got squares %v in the second frame, want the unchanged square 7 and the square 8", r.Frames[1].Squares)
+	}
+	if len(r.Frames[2].Squares) != 1 || r.Frames[2].Squares[8] == nil {
+		t.Fatalf("got squares %v in the third frame, want the square 8 only", r.Frames[2].Squares)
+	}
+}
+
+func TestFrameAtInterpolates(t *testing.T) {
+	r, err := Load(writeReplay(t, newFrames(3), true))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// the square moves by 1 every 100ms
+	frame := r.FrameAt(125 * time.Millisecond)
+	if frame.Sequence != 1 || frame.Squares[7].Position.X != 1.25 {
+		t.Fatalf("got frame %d with position %v, want frame 1 with 1.25", frame.Sequence, frame.Squares[7].Position.X)
+	}
+	if r.Frames[1].Squares[7].Position.X != 1 {
+		t.Fatal("the interpolation has changed the recorded frame")
+	}
+
+	// the respawned square jumps to the next position
+	r.Frames[2].Squares[7] = &entity.Square{Id: 7, Position: geometry.Point{X: 50}, Deaths: 1}
+	if x := r.FrameAt(150 * time.Millisecond).Squares[7].Position.X; x != 1 {
+		t.Fatalf("got position %v of the respawned square, want 1", x)
+	}
+}
+
+func TestLoadUnfinished(t *testing.T) {
+	r, err := Load(writeReplay(t, newFrames(3), false))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(r.Frames) != 3 {
+		t.Fatalf("got %d frames, want 3", len(r.Frames))
+	}
+}
+
+func TestLoadWithoutFrames(t *testing.T) {
+	if _, err := Load(writeReplay(t, nil, true)); err == nil {
+		t.Fatal("got no error for the replay without frames")
+	}
+}
+
+func TestLoadNotReplay(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "broken"+FileExtension)
+	file, err := os.Create(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	w := gzip.NewWriter(file)
+	w.Write([]byte("not json"))
+	w.Close()
+	file.Close()
+
+	if _, err = Load(path); err == nil {
+		t.Fatal("got no error for the broken replay")
+	}
+}
+
+func TestPlayer(t *testing.T) {
+	r, err := Load(writeReplay(t, newFrames(11), true))
+	if err != nil {
+		t.Fatal(err)
+	}
+	p := NewPlayer(r)
+
+	// the frame before the position is shown
+	p.Advance(250 * time.Millisecond)
+	if p.Frame().Sequence != 2 {
+		t.Fatalf("got frame %d, want 2", p.Frame().Sequence)
+	}
+
+	// the speed scales the playback
+	p.SetSpeed(2)
+	p.Advance(250 * time.Millisecond)
+	if p.Position != 750*time.Millisecond {
+		t.Fatalf("got position %v, want 750ms", p.Position)
+	}
+
+	// the speed is limited
+	p.SetSpeed(100)
+	if p.Speed != MaxSpeed {
+		t.Fatalf("got speed %v, want %v", p.Speed, MaxSpeed)
+	}
+	p.SetSpeed(0)
+	if p.Speed != MinSpeed {
+		t.Fatalf("got speed %v, want %v", p.Speed, MinSpeed)
+	}
+
+	// the paused playback doesn't move
+	p.Paused = true
+	p.Advance(time.Second)
+	if p.Position != 750*time.Millisecond {
+		t.Fatalf("got position %v after pause, want 750ms", p.Position)
+	}
+
+	// seeking is limited by the replay
+	p.Seek(-time.Second)
+	if p.Frame().Sequence != 0 {
+		t.Fatalf("got frame %d, want 0", p.Frame().Sequence)
+	}
+
+	// the playback stops at the end
+	p.Paused = false
+	p.SetSpeed(MaxSpeed)
+	p.Advance(time.Minute)
+	if !p.Paused || p.Frame().Sequence != 10 {
+		t.Fatalf("got paused %v at frame %d, want paused at the last frame", p.Paused, p.Frame().Sequence)
+	}
+}
+
+func TestLatest(t *testing.T) {
+	dir := t.TempDir()
+	if Latest(dir) != "" {
+		t.Fatal("found a replay in the empty directory")
+	}
+
+	older := filepath.Join(dir, "a"+FileExtension)
+	newer := filepath.Join(dir, "b"+FileExtension)
+	os.WriteFile(older, nil, 0644)
+	os.WriteFile(newer, nil, 0644)
+	os.WriteFile(filepath.Join(dir, "c.txt"), nil, 0644)
+	os.Chtimes(older, time.Unix(100, 0), time.Unix(100, 0))
+	os.Chtimes(newer, time.Unix(200, 0), time.Unix(200, 0))
+
+	if got := Latest(dir); got != newer {
+		t.Fatalf("got %s, want %s", got, newer)
+	}
+}
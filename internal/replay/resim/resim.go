@@ -0,0 +1,227 @@
+// Package resim simulates a recorded match again without
+// a server or a window using the recorded seed, settings and
+// inputs and checks that it goes the same way as recorded.
+package resim
+
+import (
+	"encoding/json"
+	"fmt"
+	"online_shooter/internal/game/game"
+	"online_shooter/internal/game/harness"
+	"online_shooter/internal/model"
+	"online_shooter/internal/replay"
+)
+
+// Result describes the simulated match.
+type Result struct {
+	Ticks  uint64
+	Frames int
+}
+
+// MismatchError is a difference between the recorded
+// and the simulated match found in the frame.
+type MismatchError struct {
+	Tick      uint64
+	Entity    string
+	Recorded  string
+	Simulated string
+}
+
+func (e *MismatchError) Error() string {
+	return fmt.Sprintf("%s differs at tick %d\nrecorded:  %s\nsimulated: %s",
+		e.Entity, e.Tick, e.Recorded, e.Simulated)
+}
+
+// Run simulates the recorded match again tick by tick applying
+// the recorded inputs, joins and leaves and compares the game state
+// with every recorded frame. The game variables must be set
+// from the replay header before, see config.SetGameValues.
+//
+// Accepts a pointer to the replay.
+//
+// Returns a pointer to the result and a *MismatchError
+// if the simulated match differs from the recorded one.
+func Run(r *replay.Replay) (*Result, error) {
+	settings := r.Header.Settings
+	settings.Seed = r.Header.Seed
+	h := harness.New(settings)
+
+	// the arena is generated from the seed
+	// before any tick is simulated
+	initial := harness.NewSnapshot(0, nil, r.Header.Arena.Obstacles)
+	if err := compare(0, initial, harness.NewSnapshot(0, nil, h.Game.Arena.Obstacles)); err != nil {
+		return nil, err
+	}
+
+	inputs, events := collect(r)
+
+	result := &Result{}
+	for _, frame := range r.Frames {
+		// simulate the ticks till the frame
+		for uint64(h.Tick) < frame.Tick {
+			tick := uint64(h.Tick)
+			if err := applyEvents(h, tick, events[tick]); err != nil {
+				return nil, err
+			}
+			h.Step(inputs[tick])
+		}
+
+		// apply the events which have happened after
+		// the last tick but before the frame was created,
+		// the rest of the tick's events are recorded later
+		tick := uint64(h.Tick)
+		before := countEvents(frame.Events, tick)
+		if err := applyEvents(h, tick, events[tick][:before]); err != nil {
+			return nil, err
+		}
+		events[tick] = events[tick][before:]
+
+		recorded := harness.NewSnapshot(h.Tick, frame.Squares, frame.Obstacles)
+		if err := compare(frame.Tick, recorded, h.Snapshot()); err != nil {
+			return nil, err
+		}
+		result.Frames++
+	}
+	result.Ticks = uint64(h.Tick)
+
+	return result, nil
+}
+
+// collect groups the recorded inputs and events by the ticks
+// they are applied before. The inputs of the player received
+// after the tick are merged the same way as by the server.
+//
+// Accepts a pointer to the replay.
+//
+// Returns the inputs and the events by the ticks.
+func collect(r *replay.Replay) (map[uint64]map[int64]*model.PlayerUpdateMessage, map[uint64][]replay.Event) {
+	inputs := make(map[uint64]map[int64]*model.PlayerUpdateMessage)
+	events := make(map[uint64][]replay.Event)
+
+	for _, frame := range r.Frames {
+		for _, input := range frame.Inputs {
+			if inputs[input.Tick] == nil {
+				inputs[input.Tick] = make(map[int64]*model.PlayerUpdateMessage)
+			}
+			update := input.Update
+			inputs[input.Tick][input.Player] = game.MergeInput(inputs[input.Tick][input.Player], &update)
+		}
+		for _, event := range frame.Events {
+			events[event.Tick] = append(events[event.Tick], event)
+		}
+	}
+
+	return inputs, events
+}
+
+// countEvents counts the events of the frame which have happened at the tick.
+//
+// Accepts the events of the frame and the tick.
+func countEvents(events []replay.Event, tick uint64) int {
+	count := 0
+	for _, event := range events {
+		if event.Tick == tick {
+			count++
+		}
+	}
+	return count
+}
+
+// applyEvents adds and removes the players like the server did.
+//
+// Accepts a pointer to the harness, the tick and the events
+// happened after it.
+//
+// Returns a *MismatchError if the added player
+// gets another id than the recorded one.
+func applyEvents(h *harness.Harness, tick uint64, events []replay.Event) error {
+	for _, event := range events {
+		switch event.Type {
+		case replay.EventJoin:
+			player := h.Game.AddPlayer()
+			if player == nil || player.Id != event.Player {
+				simulated := "no player"
+				if player != nil {
+					simulated = fmt.Sprintf("player %d", player.Id)
+				}
+				return &MismatchError{
+					Tick:      tick,
+					Entity:    "joined player",
+					Recorded:  fmt.Sprintf("player %d", event.Player),
+					Simulated: simulated,
+				}
+			}
+		case replay.EventLeave:
+			h.Game.RemovePlayer(event.Player)
+		}
+	}
+
+	return nil
+}
+
+// compare compares the recorded and the simulated game states.
+// Only the state stored in the replay is compared.
+//
+// Accepts the tick and pointers to the recorded
+// and the simulated snapshots.
+//
+// Returns a *MismatchError describing the first difference.
+func compare(tick uint64, recorded, simulated *harness.Snapshot) error {
+	// vulnerability and reloading of the squares are not recorded
+	for _, squares := range [][]harness.SquareState{recorded.Squares, simulated.Squares} {
+		for i := range squares {
+			squares[i].Vulnerable = false
+			squares[i].CanShoot = false
+		}
+	}
+
+	for i := 0; i < max(len(recorded.Squares), len(simulated.Squares)); i++ {
+		var want, got *harness.SquareState
+		if i < len(recorded.Squares) {
+			want = &recorded.Squares[i]
+		}
+		if i < len(simulated.Squares) {
+			got = &simulated.Squares[i]
+		}
+		if err := compareStates(tick, "square", want, got); err != nil {
+			return err
+		}
+	}
+
+	for i := 0; i < max(len(recorded.Obstacles), len(simulated.Obstacles)); i++ {
+		var want, got *harness.ObstacleState
+		if i < len(recorded.Obstacles) {
+			want = &recorded.Obstacles[i]
+		}
+		if i < len(simulated.Obstacles) {
+			got = &simulated.Obstacles[i]
+		}
+		if err := compareStates(tick, "obstacle", want, got); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// compareStates compares the encoded states of the entity.
+//
+// Accepts the tick, a kind of the entity and pointers to the
+// recorded and the simulated states which are nil if the entity
+// is missing.
+//
+// Returns a *MismatchError if the states differ.
+func compareStates[T any](tick uint64, kind string, recorded, simulated *T) error {
+	want, _ := json.Marshal(recorded)
+	got, _ := json.Marshal(simulated)
+	if string(want) == string(got) {
+		return nil
+	}
+
+	return &MismatchError{
+		Tick:      tick,
+		Entity:    kind,
+		Recorded:  string(want),
+		Simulated: string(got),
+	}
+}
@@ -3,107 +3,253 @@ package server
 import (
 	"encoding/json"
 	"github.com/gorilla/websocket"
-	"online_shooter/internal/game/entity"
+	"online_shooter/internal/config"
+	"online_shooter/internal/game/game"
 	"online_shooter/internal/logger"
 	"online_shooter/internal/model"
+	"slices"
 	"time"
 )
 
-// broadcast provides every connected client with
-// actual game state using WebSocket connection.
-func (s *Server) broadcast() {
-	// set refreshing time for the ticker
-	ticker := time.NewTicker(refreshingRate * time.Millisecond)
-	defer ticker.Stop()
-
-	// every tick updates a game state and broadcasts data to clients
-	for range ticker.C {
-		s.serverMutex.Lock()
-
-		// update game state
-		s.Update()
-
-		// create and init a new update instance
-		s.Arena.ArenaMutex.RLock()
-		s.Game.GameMutex.RLock()
-		gameUpdate := &model.GameUpdateMessage{
-			Obstacles: s.Arena.Obstacles,
-			Squares:   s.Squares,
+const writeTimeout = time.Second
+
+// client stores the connection of the player
+// and the size of the player's screen.
+// The connection is nil till the player connects.
+type client struct {
+	conn     *websocket.Conn
+	viewport viewport
+	// pending holds the latest game update waiting
+	// for writing to the connection
+	pending chan *model.GameUpdateMessage
+	// removed is closed when the player leaves the game
+	removed chan struct{}
+}
+
+// viewport is a size of the player's screen.
+type viewport struct {
+	width, height float32
+}
+
+// newClient creates a client of the player
+// waiting for the connection.
+//
+// Returns a pointer to the created client.
+func newClient() *client {
+	return &client{
+		pending: make(chan *model.GameUpdateMessage, 1),
+		removed: make(chan struct{}),
+	}
+}
+
+// publish creates game updates for every player and passes
+// them to the players' writing goroutines together with
+// the frame of the recorded match to the recording goroutine.
+// Every player gets only the part of the game state
+// inside its interest area, the state of the match and the objectives,
+// the scoreboard is sent to everyone at a lower rate
+// except the end of the round when it is final.
+func (s *Server) publish() {
+	match := s.MatchStatus()
+	flags := s.cloneFlags()
+	zones := s.cloneZones()
+	safeZone := s.cloneSafeZone()
+	pickups := s.clonePickups()
+
+	// every player gets the kills to show them in the kill feed
+	kills := s.kills
+	s.kills = nil
+
+	// collect the scoreboard if it is time to send it
+	s.sequence++
+	var scoreboard []model.PlayerStatus
+	if s.sequence%scoreboardInterval == 0 || match.State == game.MatchEnded {
+		scoreboard = s.scoreboard()
+		s.publishStatus()
+	}
+
+	// create an update for every player
+	for _, square := range s.Squares {
+		if square.IsBot {
+			continue
 		}
 
-		// lock every obstacle before marshalling
-		for _, obstacle := range gameUpdate.Obstacles {
-			obstacle.Lock()
+		c, screen := s.client(square.Id)
+		if c == nil {
+			continue
 		}
-		// lock every square before marshalling
-		for _, square := range gameUpdate.Squares {
-			square.Lock()
+		update := s.createGameUpdate(square, screen, scoreboard)
+		update.Match = match
+		update.Flags = flags
+		update.Zones = zones
+		update.SafeZone = safeZone
+		update.Pickups = pickups
+		update.Kills = kills
+		c.offer(update)
+	}
+
+	// pass the frame to the recording goroutine
+	s.recordFrame(false)
+}
+
+// client returns the client of the player.
+//
+// Accepts an id of the player.
+//
+// Returns a pointer to the client or nil if it doesn't exist
+// and the size of the player's screen.
+func (s *Server) client(id int64) (*client, viewport) {
+	s.clientsMutex.RLock()
+	defer s.clientsMutex.RUnlock()
+
+	c := s.clients[id]
+	if c == nil {
+		return nil, viewport{}
+	}
+
+	return c, c.screen()
+}
+
+// screen returns the size of the player's screen.
+// If the player hasn't sent it the server's screen size is used.
+func (c *client) screen() viewport {
+	if c.viewport.width > 0 && c.viewport.height > 0 {
+		return c.viewport
+	}
+
+	return viewport{width: config.ScreenWidth(), height: config.ScreenHeight()}
+}
+
+// offer passes the game update to the client's writing goroutine
+// without waiting for it. If the client hasn't written the previous
+// update yet it is replaced with the new one, so a slow client
+// skips updates instead of stalling the simulation.
+// It must be called only by the simulation goroutine.
+//
+// Accepts a pointer to the game update.
+func (c *client) offer(update *model.GameUpdateMessage) {
+	select {
+	case c.pending <- update:
+	default:
+		// keep the changes of the skipped update
+		select {
+		case stale := <-c.pending:
+			update = mergeUpdates(stale, update)
+		default:
 		}
 
-		// marshal the update
-		msg, _ := json.Marshal(gameUpdate)
-		// unlock every obstacle before marshalling
-		for _, obstacle := range gameUpdate.Obstacles {
-			obstacle.Unlock()
+		// the simulation is the only sender,
+		// so there is a place for the update
+		c.pending <- update
+	}
+}
+
+// mergeUpdates merges the skipped game update into the newer one,
+// so the client still learns about the squares which have left
+// its area and gets the scoreboard and the kills.
+//
+// Accepts pointers to the skipped and the newer updates.
+//
+// Returns a pointer to the merged update.
+func mergeUpdates(stale, fresh *model.GameUpdateMessage) *model.GameUpdateMessage {
+	for _, id := range stale.Left {
+		if _, ok := fresh.Squares[id]; !ok && !slices.Contains(fresh.Left, id) {
+			fresh.Left = append(fresh.Left, id)
 		}
-		// unlock every square before marshalling
-		for _, square := range gameUpdate.Squares {
-			square.Unlock()
+	}
+	for _, id := range stale.Entered {
+		if _, ok := fresh.Squares[id]; ok && !slices.Contains(fresh.Entered, id) {
+			fresh.Entered = append(fresh.Entered, id)
 		}
-		s.Game.GameMutex.RUnlock()
-		s.Arena.ArenaMutex.RUnlock()
-
-		// send the update for every player
-		for _, square := range s.Squares {
-			if !square.IsBot {
-				square.RLock()
-				if err := square.Conn.WriteMessage(websocket.TextMessage, msg); err != nil {
-					logger.Warn("error while broadcasting game state: ", err)
-				}
-				square.RUnlock()
+	}
+
+	if fresh.Scoreboard == nil {
+		fresh.Scoreboard = stale.Scoreboard
+	}
+
+	// the kills are shared by the players' updates,
+	// so they are copied instead of appended
+	if len(stale.Kills) > 0 {
+		fresh.Kills = slices.Concat(stale.Kills, fresh.Kills)
+	}
+
+	return fresh
+}
+
+// writeMessages marshals the player's game updates and
+// writes them to the connection in infinite loop, so a slow
+// connection doesn't delay the other players. If the write fails
+// the connection is closed and the player leaves the game.
+// The loop ends when the player is removed or the server is closed.
+//
+// Accepts a pointer to the player's client and the connection.
+func (s *Server) writeMessages(c *client, conn *websocket.Conn) {
+	for {
+		select {
+		case <-s.done:
+			return
+		case <-c.removed:
+			return
+		case update := <-c.pending:
+			msg, err := json.Marshal(update)
+			if err != nil {
+				logger.Warn("error while encoding game state: ", err)
+				continue
 			}
-		}
 
-		s.serverMutex.Unlock()
+			conn.SetWriteDeadline(time.Now().Add(writeTimeout))
+			if err = conn.WriteMessage(websocket.TextMessage, msg); err != nil {
+				logger.Warn("error while sending game state: ", err)
+
+				// stop reading messages, so the player is removed
+				conn.Close()
+				return
+			}
+		}
 	}
 }
 
 // readMessages reads messages from the client about the player
-// game state in infinite loop and updates the player state
-// on the server side. if the connection between client and server
+// game state in infinite loop and passes them to the
+// simulation goroutine. if the connection between client and server
 // is closed the player is removed from the game.
 //
-// Accepts a function to remove the player as an argument.
-func (s *Server) readMessages(player *entity.Square, remove func(id int64)) {
+// Accepts an id of the player and a pointer to the player's connection.
+func (s *Server) readMessages(id int64, conn *websocket.Conn) {
+	defer conn.Close()
+
 	// run an infinite loop reading messages from the client
 	for {
 		// read the message
-		player.RLock()
-		_, msg, err := player.Conn.ReadMessage()
+		_, msg, err := conn.ReadMessage()
 		if err != nil {
 			if websocket.IsCloseError(err, websocket.CloseNormalClosure, websocket.CloseGoingAway) {
-				logger.Info("player disconnected: ", player.Id)
+				logger.Info("player disconnected: ", id)
 			} else {
-				logger.Warn("failed to read a message from the client ", player.Id, ": ", err)
-
+				logger.Warn("failed to read a message from the client ", id, ": ", err)
 			}
 			break
 		}
-		player.RUnlock()
 
 		// decode the message
 		var updatePlayerMessage model.PlayerUpdateMessage
 		err = json.Unmarshal(msg, &updatePlayerMessage)
 		if err != nil {
-			logger.Warn("failed to decode a message from the client ", player.Id, ": ", err)
-		} else {
-			// add player update to the slice
-			s.serverMutex.Lock()
-			s.playerUpdates[player.Id] = &updatePlayerMessage
-			s.serverMutex.Unlock()
+			logger.Warn("failed to decode a message from the client ", id, ": ", err)
+			continue
+		}
+
+		// pass the player update to the simulation
+		select {
+		case s.inputs <- playerInput{id: id, msg: &updatePlayerMessage}:
+		case <-s.done:
+			return
 		}
 	}
 
 	// remove the player from the game
-	remove(player.Id)
+	select {
+	case s.leaves <- id:
+	case <-s.done:
+	}
 }
@@ -0,0 +1,79 @@
+package server
+
+import (
+	"online_shooter/internal/game/arena"
+	"online_shooter/internal/game/entity"
+	"online_shooter/internal/game/game"
+	"online_shooter/internal/model"
+	"online_shooter/internal/tlsutil"
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestPublishSkipsUpdatesForSlowClient(t *testing.T) {
+	s, err := NewServer(&game.ServerSettings{
+		PlayerCount:   2,
+		ObstacleLevel: arena.LowObstaclesAmount,
+		TLSMode:       tlsutil.ModeOff,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	result := s.addPlayer()
+
+	// nobody writes the updates of the player
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for i := 0; i < 100; i++ {
+			s.publish()
+		}
+	}()
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("the simulation is blocked by the client")
+	}
+
+	c, _ := s.client(result.player.Id)
+	update := <-c.pending
+	if update.Sequence != 100 {
+		t.Fatalf("got the update %d, want the latest one", update.Sequence)
+	}
+}
+
+func TestMergeUpdates(t *testing.T) {
+	scoreboard := []model.PlayerStatus{{Id: 1}}
+	stale := &model.GameUpdateMessage{
+		Squares:    map[int64]*entity.Square{1: {}, 3: {}},
+		Entered:    []int64{3},
+		Left:       []int64{2, 4},
+		Scoreboard: scoreboard,
+		Kills:      []model.KillEvent{{Victim: 2}},
+	}
+	fresh := &model.GameUpdateMessage{
+		Squares: map[int64]*entity.Square{1: {}, 4: {}},
+		Entered: []int64{4},
+		Left:    []int64{3},
+		Kills:   []model.KillEvent{{Victim: 3}},
+	}
+
+	merged := mergeUpdates(stale, fresh)
+
+	// the square 2 has left in the skipped update and
+	// the square 4 has left and entered again
+	slices.Sort(merged.Left)
+	if !slices.Equal(merged.Left, []int64{2, 3}) {
+		t.Fatalf("got left %v, want [2 3]", merged.Left)
+	}
+	if !slices.Equal(merged.Entered, []int64{4}) {
+		t.Fatalf("got entered %v, want [4]", merged.Entered)
+	}
+	if len(merged.Scoreboard) != 1 {
+		t.Fatal("the skipped scoreboard is lost")
+	}
+	if len(merged.Kills) != 2 || merged.Kills[0].Victim != 2 {
+		t.Fatalf("got kills %+v, want the skipped kill first", merged.Kills)
+	}
+}
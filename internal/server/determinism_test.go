@@ -0,0 +1,62 @@
+package server
+
+import (
+	"bytes"
+	"encoding/json"
+	"online_shooter/internal/game/arena"
+	"online_shooter/internal/game/clock"
+	"online_shooter/internal/game/game"
+	"online_shooter/internal/tlsutil"
+	"testing"
+	"time"
+)
+
+// simulateWithSeed runs the game with bots only
+// using a manual clock and the accepted seed.
+//
+// Returns the marshalled final state of the game.
+func simulateWithSeed(t *testing.T, seed int64, ticks int) []byte {
+	t.Helper()
+
+	s, err := NewServer(&game.ServerSettings{
+		PlayerCount:   8,
+		ObstacleLevel: arena.HighObstaclesAmount,
+		TLSMode:       tlsutil.ModeOff,
+		Seed:          seed,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	c := clock.NewManual(time.Unix(0, 0))
+	s.clock = c
+
+	for i := 0; i < ticks; i++ {
+		s.Update()
+		c.Advance(game.TickDuration)
+	}
+
+	state, err := json.Marshal(struct {
+		Arena   *arena.Arena
+		Squares any
+	}{s.Arena, s.Squares})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return state
+}
+
+func TestSimulationIsDeterministic(t *testing.T) {
+	const ticks = 600
+
+	first := simulateWithSeed(t, 42, ticks)
+	second := simulateWithSeed(t, 42, ticks)
+	if !bytes.Equal(first, second) {
+		t.Fatal("games with the same seed ended in different states")
+	}
+
+	other := simulateWithSeed(t, 43, ticks)
+	if bytes.Equal(first, other) {
+		t.Fatal("games with different seeds ended in the same state")
+	}
+}
@@ -6,12 +6,15 @@ import (
 	"github.com/go-chi/chi/v5"
 	"github.com/gorilla/websocket"
 	"net/http"
-	"online_shooter/internal/game/entity"
 	"online_shooter/internal/logger"
 	"online_shooter/internal/model"
 	"online_shooter/internal/utils"
+	"strconv"
 )
 
+// maxViewportSize limits the screen size sent by the players
+const maxViewportSize = 4096
+
 // upgrader is used for creating a websocket connection from http connection
 var upgrader = websocket.Upgrader{
 	CheckOrigin: func(r *http.Request) bool { return true },
@@ -20,12 +23,24 @@ var upgrader = websocket.Upgrader{
 // createPlayerHandler handles an http request from the client
 // creating a new player in the game and response sending
 // an arena and the created player instances to the client.
+// If there is no space for a new player responds
+// with the service unavailable status.
 func (s *Server) createPlayerHandler(w http.ResponseWriter, r *http.Request) {
-	// create and init a new player instance
-	player := entity.NewPlayer(nil)
+	// ask the simulation to add a new player
+	reply := make(chan *joinResult, 1)
+	select {
+	case s.joins <- reply:
+	case <-s.done:
+		http.Error(w, "the server is closed", http.StatusServiceUnavailable)
+		return
+	}
+	result := <-reply
 
-	// add the created player to the server as a new player
-	s.addPlayer(player)
+	// check if the player is added
+	if result.player == nil {
+		http.Error(w, "there is no space for a new player", http.StatusServiceUnavailable)
+		return
+	}
 
 	// send created status
 	w.Header().Set("Content-Type", "application/json")
@@ -33,8 +48,8 @@ func (s *Server) createPlayerHandler(w http.ResponseWriter, r *http.Request) {
 
 	// encode a response providing the created player and the game arena
 	response := &model.CreatePlayerResponse{
-		Arena:  s.Arena,
-		Player: player,
+		Arena:  result.arena,
+		Player: result.player,
 	}
 	err := json.NewEncoder(w).Encode(response)
 	if err != nil {
@@ -52,10 +67,17 @@ func (s *Server) connectPlayerHandler(w http.ResponseWriter, r *http.Request) {
 	// get player id from the url param
 	id, err := utils.StringToInt64(chi.URLParam(r, "id"))
 	if err != nil {
+		http.Error(w, "invalid player id", http.StatusBadRequest)
 		logger.Warn("error while parsing url param: ", err)
 		return
 	}
 
+	// check if the player is created and not connected yet
+	if !s.isWaitingForConnection(id) {
+		http.Error(w, "player not found", http.StatusNotFound)
+		return
+	}
+
 	// create and init a new websocket connection
 	conn, err := upgrader.Upgrade(w, r, nil)
 	if err != nil {
@@ -64,22 +86,70 @@ func (s *Server) connectPlayerHandler(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// update player's connection field
-	s.serverMutex.Lock()
-	s.Squares[id].Conn = conn
-	player := s.Squares[id]
-	s.serverMutex.Unlock()
-
-	// create a callback function to remove the player from the server when they disconnect
-	// this function will be called by ReadMessages if the player's connection is closed
-	removePlayerFunc := func(id int64) {
+	s.clientsMutex.Lock()
+	c := s.clients[id]
+	if c != nil && c.conn == nil {
+		c.conn = conn
+		c.viewport = parseViewport(r)
+	}
+	s.clientsMutex.Unlock()
+
+	// close the connection if the player has been removed
+	// or connected from another connection in the meantime
+	if c == nil || c.conn != conn {
 		conn.Close()
-		s.removePlayer(id)
+		return
 	}
 
+	// measure the player's ping using pong messages
+	conn.SetPongHandler(func(payload string) error {
+		s.handlePong(id, payload)
+		return nil
+	})
+
 	// log the player connection
 	logger.Info(fmt.Sprintf("player%d connected to the server", id))
 
+	// start pinging the player
+	go s.pingPlayer(conn)
+
+	// start writing the game updates to the player
+	go s.writeMessages(c, conn)
+
 	// start reading messages from the player
-	// the callback function is passed to handle player disconnection
-	go s.readMessages(player, removePlayerFunc)
+	go s.readMessages(id, conn)
+}
+
+// isWaitingForConnection checks if the player with accepted id
+// is created and doesn't have a connection.
+//
+// Accepts an id of the player.
+//
+// Returns true if the player is waiting for the connection, otherwise false.
+func (s *Server) isWaitingForConnection(id int64) bool {
+	s.clientsMutex.RLock()
+	defer s.clientsMutex.RUnlock()
+
+	c := s.clients[id]
+	return c != nil && c.conn == nil
+}
+
+// parseViewport reads the size of the player's screen
+// from the query parameters of the connection request.
+//
+// Accepts a pointer to the request.
+//
+// Returns the size of the screen which is zero
+// if the parameters are missing or invalid.
+func parseViewport(r *http.Request) viewport {
+	width, err := strconv.ParseFloat(r.URL.Query().Get(ViewportWidthParam), 32)
+	if err != nil || width <= 0 || width > maxViewportSize {
+		return viewport{}
+	}
+	height, err := strconv.ParseFloat(r.URL.Query().Get(ViewportHeightParam), 32)
+	if err != nil || height <= 0 || height > maxViewportSize {
+		return viewport{}
+	}
+
+	return viewport{width: float32(width), height: float32(height)}
 }
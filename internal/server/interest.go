@@ -0,0 +1,203 @@
+package server
+
+import (
+	"online_shooter/internal/game/arena"
+	"online_shooter/internal/game/camera"
+	"online_shooter/internal/game/entity"
+	"online_shooter/internal/game/geometry"
+	"online_shooter/internal/model"
+)
+
+const (
+	// interestMarginFactor is a part of the screen size
+	// added to every side of the player's interest area
+	interestMarginFactor = 0.25
+
+	// scoreboardInterval is an amount of snapshots
+	// between two scoreboard updates
+	scoreboardInterval = 30
+)
+
+// createGameUpdate creates a game update for the player
+// containing only the squares and the obstacles
+// inside the player's interest area. Squares which have
+// entered or left the area since the previous update are listed
+// in the update. The update contains copies of the squares
+// and the obstacles so it doesn't share state with the game.
+// The eliminated player gets the area of the square it spectates.
+//
+// Accepts a pointer to the player, the size of the player's screen
+// and the scoreboard which is nil if it is not sent in this update.
+//
+// Returns a pointer to the created update.
+func (s *Server) createGameUpdate(player *entity.Square, viewport viewport, scoreboard []model.PlayerStatus) *model.GameUpdateMessage {
+	gameUpdate := &model.GameUpdateMessage{
+		Sequence:   s.sequence,
+		Obstacles:  make(map[int64]*arena.Obstacle),
+		Squares:    make(map[int64]*entity.Square),
+		Scoreboard: scoreboard,
+	}
+
+	// the eliminated player watches the nearest alive square
+	watched := player
+	if player.Eliminated {
+		if target := s.SpectatedSquare(player); target != nil {
+			watched = target
+			gameUpdate.Spectating = target.Id
+		}
+	}
+	area := interestArea(watched, viewport, s.Arena.Width, s.Arena.Height)
+
+	// collect the obstacles inside the area
+	for _, o := range s.QueryObstacles(area, nil) {
+		if area.Intersects(o.Position, o.Size) {
+			gameUpdate.Obstacles[o.Id] = o.Clone()
+		}
+	}
+
+	// collect the squares inside the area
+	gameUpdate.Squares[player.Id] = player.Clone()
+	for _, square := range s.QuerySquares(area, nil) {
+		if square.Id != player.Id && isSquareInArea(square, area) {
+			gameUpdate.Squares[square.Id] = square.Clone()
+		}
+	}
+
+	// get the squares which have entered or left the area
+	gameUpdate.Entered, gameUpdate.Left = s.updateVisibility(player.Id, gameUpdate.Squares)
+
+	return gameUpdate
+}
+
+// cloneFlags creates copies of the flags to send them to the clients.
+//
+// Returns the copies or nil if there are no flags in the game mode.
+func (s *Server) cloneFlags() []*entity.Flag {
+	if len(s.Flags) == 0 {
+		return nil
+	}
+
+	flags := make([]*entity.Flag, len(s.Flags))
+	for i, f := range s.Flags {
+		flags[i] = f.Clone()
+	}
+	return flags
+}
+
+// cloneZones creates copies of the control zones to send them to the clients.
+//
+// Returns the copies or nil if there are no zones in the game mode.
+func (s *Server) cloneZones() []*entity.Zone {
+	if len(s.Zones) == 0 {
+		return nil
+	}
+
+	zones := make([]*entity.Zone, len(s.Zones))
+	for i, z := range s.Zones {
+		zones[i] = z.Clone()
+	}
+	return zones
+}
+
+// clonePickups creates copies of the pickups to send them to the clients.
+//
+// Returns the copies or nil if there are no pickups on the arena.
+func (s *Server) clonePickups() []*entity.Pickup {
+	if len(s.Arena.Pickups) == 0 {
+		return nil
+	}
+
+	pickups := make([]*entity.Pickup, len(s.Arena.Pickups))
+	for i, p := range s.Arena.Pickups {
+		pickups[i] = p.Clone()
+	}
+	return pickups
+}
+
+// cloneSafeZone creates a copy of the safe zone to send it to the clients.
+//
+// Returns the copy or nil if there is no safe zone in the game mode.
+func (s *Server) cloneSafeZone() *entity.SafeZone {
+	if s.SafeZone == nil {
+		return nil
+	}
+	return s.SafeZone.Clone()
+}
+
+// updateVisibility compares the squares visible for the player
+// with the squares visible in the previous update and stores
+// the new visible squares.
+//
+// Accepts an id of the player and a map of the visible squares.
+//
+// Returns ids of the squares that have entered the
+// player's interest area and ids of the squares that have left it.
+func (s *Server) updateVisibility(id int64, squares map[int64]*entity.Square) ([]int64, []int64) {
+	var entered, left []int64
+
+	previous := s.visibleSquares[id]
+	current := make(map[int64]bool, len(squares))
+
+	// find the squares that have entered the area
+	for squareId := range squares {
+		current[squareId] = true
+		if !previous[squareId] {
+			entered = append(entered, squareId)
+		}
+	}
+
+	// find the squares that have left the area
+	for squareId := range previous {
+		if !current[squareId] {
+			left = append(left, squareId)
+		}
+	}
+
+	s.visibleSquares[id] = current
+
+	return entered, left
+}
+
+// interestArea counts the area which is visible for the player's
+// camera with a margin. The camera follows the player but stops
+// at the arena borders, so near them the player is not
+// in the center of the area.
+//
+// Accepts a pointer to the player, the size of the player's screen
+// and the arena sizes.
+//
+// Returns the player's interest area.
+func interestArea(player *entity.Square, viewport viewport, arenaWidth, arenaHeight float32) geometry.Rect {
+	view := camera.ViewAt(player.Position, viewport.width, viewport.height, arenaWidth, arenaHeight)
+
+	// add the margin to every side
+	marginX := viewport.width * interestMarginFactor
+	marginY := viewport.height * interestMarginFactor
+
+	return geometry.Rect{
+		X:      view.X - marginX,
+		Y:      view.Y - marginY,
+		Width:  view.Width + 2*marginX,
+		Height: view.Height + 2*marginY,
+	}
+}
+
+// isSquareInArea checks if the square or
+// any of its bullets is inside the area.
+//
+// Accepts a pointer to the square and the area.
+//
+// Returns true if the square is inside the area, otherwise false.
+func isSquareInArea(square *entity.Square, area geometry.Rect) bool {
+	if area.Intersects(square.Position, square.Size) {
+		return true
+	}
+
+	for _, b := range square.Bullets {
+		if b != nil && area.Intersects(b.Position, b.Size) {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,48 @@
+package server
+
+import (
+	"online_shooter/internal/game/entity"
+	"online_shooter/internal/game/geometry"
+	"testing"
+)
+
+func TestInterestAreaAtArenaCorner(t *testing.T) {
+	player := &entity.Square{Position: geometry.Point{X: 10, Y: 10}, Size: 40}
+	screen := viewport{width: 1280, height: 720}
+
+	area := interestArea(player, screen, 2560, 1440)
+
+	// the camera stops at the corner and shows the whole screen
+	// to the right and to the bottom of the player
+	corners := []geometry.Point{
+		{X: 0, Y: 0},
+		{X: 1270, Y: 0},
+		{X: 0, Y: 710},
+		{X: 1270, Y: 710},
+	}
+	for _, corner := range corners {
+		if !area.Intersects(corner, 10) {
+			t.Errorf("the screen corner %v is outside the interest area %v", corner, area)
+		}
+	}
+
+	// the margin doesn't reach the far side of the arena
+	if area.Intersects(geometry.Point{X: 1700, Y: 100}, 10) {
+		t.Errorf("the interest area %v includes a point beyond the margin", area)
+	}
+}
+
+func TestInterestAreaUsesClientScreen(t *testing.T) {
+	player := &entity.Square{Position: geometry.Point{X: 1280, Y: 720}, Size: 40}
+
+	small := interestArea(player, viewport{width: 640, height: 360}, 2560, 1440)
+	large := interestArea(player, viewport{width: 1920, height: 1080}, 2560, 1440)
+
+	point := geometry.Point{X: 1280 + 700, Y: 720}
+	if small.Intersects(point, 10) {
+		t.Errorf("the small screen's interest area %v includes %v", small, point)
+	}
+	if !large.Intersects(point, 10) {
+		t.Errorf("the large screen's interest area %v doesn't include %v", large, point)
+	}
+}
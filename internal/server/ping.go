@@ -0,0 +1,58 @@
+package server
+
+import (
+	"github.com/gorilla/websocket"
+	"online_shooter/internal/logger"
+	"online_shooter/internal/netstats"
+	"time"
+)
+
+const (
+	pingInterval     = time.Second
+	pingWriteTimeout = time.Second
+	maxPing          = 65535
+)
+
+// pingPlayer sends ping messages with the sending time
+// to the player in infinite loop. The player answers
+// with pong messages which are used to count the round trip time.
+// The loop ends when the connection is closed.
+//
+// Accepts a pointer to the player's connection.
+func (s *Server) pingPlayer(conn *websocket.Conn) {
+	ticker := time.NewTicker(pingInterval)
+	defer ticker.Stop()
+
+	for range ticker.C {
+		now := time.Now()
+		err := conn.WriteControl(websocket.PingMessage, netstats.PingPayload(now), now.Add(pingWriteTimeout))
+		if err != nil {
+			// the connection is closed
+			return
+		}
+	}
+}
+
+// handlePong counts the player's round trip time
+// using the pong message payload and passes it
+// to the simulation to be applied on the next update.
+//
+// Accepts an id of the player and the pong message payload.
+func (s *Server) handlePong(id int64, payload string) {
+	rtt, err := netstats.ParsePongPayload(payload, time.Now())
+	if err != nil {
+		logger.Warn("failed to parse a pong message from the client ", id, ": ", err)
+		return
+	}
+
+	// limit the ping to fit the field
+	ping := rtt.Milliseconds()
+	if ping > maxPing {
+		ping = maxPing
+	}
+
+	select {
+	case s.pings <- playerPing{id: id, ping: uint16(ping)}:
+	case <-s.done:
+	}
+}
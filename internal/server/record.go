@@ -0,0 +1,223 @@
+package server
+
+import (
+	"online_shooter/internal/config"
+	"online_shooter/internal/game/arena"
+	"online_shooter/internal/game/entity"
+	"online_shooter/internal/game/game"
+	"online_shooter/internal/logger"
+	"online_shooter/internal/model"
+	"online_shooter/internal/replay"
+	"path/filepath"
+	"slices"
+	"time"
+)
+
+// framesInterval is an amount of ticks between two frames
+// of the recorded match, the replay interpolates the squares
+// between the frames
+const framesInterval = 3
+
+// recording stores the match data collected
+// by the simulation between two frames.
+type recording struct {
+	path     string
+	recorder *replay.Recorder
+	inputs   []replay.Input
+	events   []replay.Event
+	// squares and obstacles store copies of the entities
+	// of the last frame to record them only when they change
+	squares   map[int64]*entity.Square
+	obstacles map[int64]arena.Obstacle
+	// written is closed when all the frames are written
+	written chan struct{}
+}
+
+// setupRecording creates the replay file of the match
+// if the recording is enabled in the settings.
+func (s *Server) setupRecording() {
+	if !s.settings.Record {
+		return
+	}
+
+	header := &replay.Header{
+		Seed:      s.Seed,
+		Settings:  *s.settings,
+		Config:    config.GameValues(),
+		Arena:     s.Arena.Clone(),
+		StartedAt: s.startedAt,
+	}
+	path := filepath.Join(config.RecordingsDir(), replay.FileName(s.startedAt, s.Seed))
+
+	recorder, err := replay.NewRecorder(path, header)
+	if err != nil {
+		logger.Warn("failed to start recording the match: ", err)
+		return
+	}
+	logger.Info("the match is recorded to ", path)
+
+	s.recording = &recording{
+		path:      path,
+		recorder:  recorder,
+		squares:   make(map[int64]*entity.Square),
+		obstacles: make(map[int64]arena.Obstacle),
+		written:   make(chan struct{}),
+	}
+	for id, o := range s.Arena.Obstacles {
+		s.recording.obstacles[id] = *o
+	}
+
+	// start writing the frames, the goroutine ends
+	// when the server is closed
+	go s.record()
+}
+
+// recordInput stores the player's update received before the
+// next tick if the match is recorded. Every received update is
+// stored, the last one of the player is applied during the tick.
+//
+// Accepts an id of the player and a pointer to the update.
+func (s *Server) recordInput(id int64, upd *model.PlayerUpdateMessage) {
+	if s.recording == nil {
+		return
+	}
+
+	s.recording.inputs = append(s.recording.inputs, replay.Input{
+		Tick:   s.tick,
+		Player: id,
+		Update: *upd,
+	})
+}
+
+// recordEvent stores the event if the match is recorded.
+//
+// Accepts a type of the event and an id of the player.
+func (s *Server) recordEvent(eventType string, id int64) {
+	if s.recording == nil {
+		return
+	}
+
+	s.recording.events = append(s.recording.events, replay.Event{
+		Tick:   s.tick,
+		Type:   eventType,
+		Player: id,
+	})
+}
+
+// createFrame creates a frame of the recorded match every
+// frames interval containing copies of the squares and the obstacles
+// which have changed since the previous frame and the collected
+// inputs and events.
+//
+// Accepts true if the frame must be created
+// regardless of the interval, for example for the last frame.
+//
+// Returns a pointer to the created frame or nil
+// if the match is not recorded or it is not time for the frame.
+func (s *Server) createFrame(force bool) *replay.Frame {
+	if s.recording == nil {
+		return nil
+	}
+	if !force && s.sequence%framesInterval != 0 {
+		return nil
+	}
+
+	frame := &replay.Frame{
+		Sequence: s.sequence,
+		Tick:     s.tick,
+		Time:     time.Duration(s.tick) * game.TickDuration,
+		Inputs:   s.recording.inputs,
+		Events:   s.recording.events,
+	}
+	s.recording.inputs = nil
+	s.recording.events = nil
+
+	// record the changed squares
+	for id, square := range s.Squares {
+		if last := s.recording.squares[id]; last != nil && !isSquareChanged(last, square) {
+			continue
+		}
+		if frame.Squares == nil {
+			frame.Squares = make(map[int64]*entity.Square)
+		}
+		frame.Squares[id] = square.Clone()
+		s.recording.squares[id] = frame.Squares[id]
+	}
+
+	// record the removed squares
+	for id := range s.recording.squares {
+		if _, ok := s.Squares[id]; !ok {
+			frame.Removed = append(frame.Removed, id)
+			delete(s.recording.squares, id)
+		}
+	}
+	slices.Sort(frame.Removed)
+
+	// record the changed obstacles
+	for id, o := range s.Arena.Obstacles {
+		last := s.recording.obstacles[id]
+		if last.Health == o.Health && last.Size == o.Size && last.Vulnerable == o.Vulnerable {
+			continue
+		}
+		if frame.Obstacles == nil {
+			frame.Obstacles = make(map[int64]*arena.Obstacle)
+		}
+		frame.Obstacles[id] = o.Clone()
+		s.recording.obstacles[id] = *o
+	}
+
+	return frame
+}
+
+// isSquareChanged compares the recorded copy of the square
+// with the square. Only the fields stored in the replay are compared.
+//
+// Accepts pointers to the recorded copy and the square.
+//
+// Returns true if the square has changed, otherwise false.
+func isSquareChanged(last, square *entity.Square) bool {
+	if last.Position != square.Position || last.Health != square.Health ||
+		last.Armor != square.Armor || last.Speed != square.Speed || last.Size != square.Size ||
+		last.Kills != square.Kills || last.Deaths != square.Deaths || last.Dead != square.Dead ||
+		last.Ping != square.Ping || last.IsBot != square.IsBot ||
+		last.Color != square.Color || last.WeaponKind != square.WeaponKind ||
+		len(last.Bullets) != len(square.Bullets) || !slices.Equal(last.Effects, square.Effects) {
+		return true
+	}
+
+	for i, b := range square.Bullets {
+		lastBullet := last.Bullets[i]
+		if (b == nil) != (lastBullet == nil) || b != nil && *b != *lastBullet {
+			return true
+		}
+	}
+
+	return false
+}
+
+// recordFrame passes the frame of the recorded match
+// to the recording goroutine. The simulation waits for the place
+// in the buffer, so no frame is lost, but the slow clients
+// don't delay it as they are written by other goroutines.
+//
+// Accepts true if the frame must be created
+// regardless of the frames interval.
+func (s *Server) recordFrame(force bool) {
+	if frame := s.createFrame(force); frame != nil {
+		s.frames <- frame
+	}
+}
+
+// record writes the frames of the recorded match passed
+// by the simulation goroutine. The loop ends when the frames
+// channel is closed after the simulation has stopped,
+// so every created frame is written.
+func (s *Server) record() {
+	defer close(s.recording.written)
+
+	for frame := range s.frames {
+		if err := s.recording.recorder.WriteFrame(frame); err != nil {
+			logger.Warn("failed to record the match: ", err)
+		}
+	}
+}
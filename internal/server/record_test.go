@@ -0,0 +1,146 @@
+package server
+
+import (
+	"encoding/json"
+	"errors"
+	"github.com/gorilla/websocket"
+	"math/rand"
+	"online_shooter/internal/config"
+	"online_shooter/internal/game/arena"
+	"online_shooter/internal/game/game"
+	"online_shooter/internal/game/geometry"
+	"online_shooter/internal/model"
+	"online_shooter/internal/replay"
+	"online_shooter/internal/replay/resim"
+	"online_shooter/internal/tlsutil"
+	"os"
+	"testing"
+	"time"
+)
+
+// recordMatch records a match where a player joins, moves,
+// shoots and leaves and another player joins after it.
+//
+// Returns a pointer to the closed server, the loaded replay
+// and an id of the first player.
+func recordMatch(t *testing.T) (*Server, *replay.Replay, int64) {
+	t.Helper()
+
+	// the directory is read from the environment once
+	os.Setenv("RECORDINGS_DIR", t.TempDir())
+	defer os.Unsetenv("RECORDINGS_DIR")
+
+	s, err := NewServer(&game.ServerSettings{
+		PlayerCount:   4,
+		ObstacleLevel: arena.LowObstaclesAmount,
+		TLSMode:       tlsutil.ModeOff,
+		Record:        true,
+		Seed:          5,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	s.start()
+	ts := httpTestServer(t, s)
+
+	// play the match for a while
+	r := rand.New(rand.NewSource(1))
+	var first int64
+	for session := 0; session < 2; session++ {
+		id, _ := createPlayer(t, ts)
+		if session == 0 {
+			first = id
+		}
+		conn, err := connectPlayer(ts, id)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for i := 0; i < 20; i++ {
+			msg, _ := json.Marshal(&model.PlayerUpdateMessage{
+				RightKeyPressed: true,
+				DownKeyPressed:  r.Intn(2) == 0,
+				Shot:            r.Intn(2) == 0,
+				Aim:             geometry.Point{X: r.Float32() * 1000, Y: r.Float32() * 1000},
+			})
+			conn.WriteMessage(websocket.TextMessage, msg)
+			time.Sleep(10 * time.Millisecond)
+		}
+		conn.WriteMessage(websocket.CloseMessage,
+			websocket.FormatCloseMessage(websocket.CloseNormalClosure, ""))
+		conn.Close()
+		time.Sleep(50 * time.Millisecond)
+	}
+	s.Close()
+
+	// load the recorded match
+	t.Cleanup(func() { os.Remove(s.recording.path) })
+	loaded, err := replay.Load(s.recording.path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return s, loaded, first
+}
+
+func TestRecordMatch(t *testing.T) {
+	s, r, id := recordMatch(t)
+
+	if r.Header.Seed != 5 || len(r.Header.Arena.Obstacles) == 0 || len(r.Header.Config) == 0 {
+		t.Fatalf("got header %+v", r.Header)
+	}
+
+	var joined, left, inputs bool
+	for _, frame := range r.Frames {
+		for _, event := range frame.Events {
+			if event.Type == replay.EventJoin && event.Player == id {
+				joined = true
+			}
+			if event.Type == replay.EventLeave && event.Player == id {
+				left = true
+			}
+		}
+		for _, input := range frame.Inputs {
+			if input.Player == id && input.Update.RightKeyPressed {
+				inputs = true
+			}
+		}
+		if len(frame.Squares) != 4 {
+			t.Fatalf("got %d squares in the frame, want 4", len(frame.Squares))
+		}
+	}
+
+	// every tick till the server is closed is recorded
+	if last := r.Frames[len(r.Frames)-1]; last.Sequence != s.sequence || last.Tick != s.tick {
+		t.Fatalf("got the last frame %d at tick %d, want %d at tick %d", last.Sequence, last.Tick, s.sequence, s.tick)
+	}
+	if !joined || !left || !inputs {
+		t.Fatalf("got joined %v left %v inputs %v, want the player's events and inputs recorded", joined, left, inputs)
+	}
+}
+
+func TestRecordedMatchIsSimulatedAgain(t *testing.T) {
+	_, r, _ := recordMatch(t)
+	config.SetGameValues(r.Header.Config)
+
+	result, err := resim.Run(r)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.Frames != len(r.Frames) || result.Ticks != r.Frames[len(r.Frames)-1].Tick {
+		t.Fatalf("got %d frames and %d ticks simulated, want the whole match", result.Frames, result.Ticks)
+	}
+
+	// a changed square is found at the frame
+	frame := r.Frames[len(r.Frames)/2]
+	for id, square := range frame.Squares {
+		changed := square.Clone()
+		changed.Position.X += 10
+		frame.Squares[id] = changed
+		break
+	}
+
+	var mismatch *resim.MismatchError
+	if _, err = resim.Run(r); !errors.As(err, &mismatch) || mismatch.Tick != frame.Tick {
+		t.Fatalf("got %v, want a mismatch at tick %d", err, frame.Tick)
+	}
+}
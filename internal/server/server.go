@@ -1,13 +1,21 @@
 package server
 
 import (
+	"crypto/tls"
+	"fmt"
 	"github.com/go-chi/chi/v5"
+	"net"
 	"net/http"
+	"online_shooter/internal/config"
+	"online_shooter/internal/game/clock"
 	"online_shooter/internal/game/game"
 	"online_shooter/internal/logger"
-	"online_shooter/internal/menu"
 	"online_shooter/internal/model"
+	"online_shooter/internal/replay"
+	"online_shooter/internal/tlsutil"
+	"os"
 	"sync"
+	"sync/atomic"
 	"time"
 )
 
@@ -17,52 +25,269 @@ const (
 
 	CreatePlayerPostfix  = "/player/create"
 	ConnectPlayerPostfix = "/connect/"
+	StatusPostfix        = "/status"
+
+	// query parameters of the connection
+	// with the size of the player's screen
+	ViewportWidthParam  = "width"
+	ViewportHeightParam = "height"
+
+	selfSignedCertFile = "./server_cert.pem"
 )
 
+// Server owns the game simulation. The game state is changed
+// only by the simulation goroutine, the connection handlers
+// communicate with it using channels and the clients get
+// immutable copies of the game state.
 type Server struct {
 	game.Game
-	serverMutex   sync.RWMutex
+
+	// the fields below are owned by the simulation goroutine
 	playerUpdates map[int64]*model.PlayerUpdateMessage
-	lastUpdate    *time.Time
+	playerPings   map[int64]uint16
+	// visibleSquares stores ids of the squares
+	// every player got in the previous update
+	visibleSquares map[int64]map[int64]bool
+	lastUpdate     *time.Time
+	startedAt      time.Time
+	sequence       uint64
+	// kills are the kill events simulated since the last update
+	kills []model.KillEvent
+	// tick is an amount of the simulated ticks and
+	// lag is the elapsed time which is not simulated yet
+	tick  uint64
+	lag   time.Duration
+	clock clock.Clock
+	// recording is nil if the match is not recorded
+	recording *recording
+
+	// channels to communicate with the simulation goroutine
+	inputs    chan playerInput
+	pings     chan playerPing
+	joins     chan chan *joinResult
+	leaves    chan int64
+	frames    chan *replay.Frame
+	done      chan struct{}
+	closeOnce sync.Once
+	running   sync.WaitGroup
+
+	// clients stores connections of the players
+	clientsMutex sync.RWMutex
+	clients      map[int64]*client
+
+	// status is published by the simulation goroutine
+	status atomic.Pointer[model.StatusResponse]
+
+	settings    *game.ServerSettings
+	tlsConfig   *tls.Config
+	certificate []byte
 }
 
-// Run initializes and starts server listening an interface.
-// Server accepts http requests and maintain websocket connection
-// with clients using broadcast function to send the game state.
-func (s *Server) Run(settings *menu.ServerSettings) {
-	// create and init a new router instance
-	r := chi.NewRouter()
+// NewServer creates and initializes a new server instance
+// with a new game and a tls configuration.
+//
+// Accepts a pointer to the server settings instance.
+//
+// Returns a pointer to the created server and an error
+// if the server can't be set up, otherwise nil.
+func NewServer(settings *game.ServerSettings) (*Server, error) {
+	s := &Server{
+		settings: settings,
+		clock:    clock.Real{},
+	}
 
 	// set up the server
-	s.setup(settings)
+	if err := s.setup(); err != nil {
+		return nil, err
+	}
 
+	return s, nil
+}
+
+// Run starts server listening an interface.
+// Server accepts http requests and maintain websocket connection
+// with clients writing the game state to every connection.
+// If tls is enabled server accepts https and wss connections only.
+func (s *Server) Run() {
 	// change tcp network address whether the server is public or not
 	var url string
-	if settings.IsPublic {
+	if s.settings.IsPublic {
 		url = publicInterface
 	} else {
 		url = PrivateInterface
 	}
 
-	// add handlers
-	r.Post(CreatePlayerPostfix, s.createPlayerHandler)
-	r.Get(ConnectPlayerPostfix+"{id}", s.connectPlayerHandler)
+	// start the simulation
+	s.start()
 
-	// start broadcasting server state
-	go s.broadcast()
+	// create an http server instance
+	httpServer := &http.Server{
+		Addr:      url,
+		Handler:   s.routes(),
+		TLSConfig: s.tlsConfig,
+	}
 
 	// listen on address
-	err := http.ListenAndServe(url, r)
+	var err error
+	if s.tlsConfig != nil {
+		// certificates are already in the tls config
+		err = httpServer.ListenAndServeTLS("", "")
+	} else {
+		err = httpServer.ListenAndServe()
+	}
 	if err != nil {
 		logger.Fatal("error while listening on server: ", err)
 	}
 }
 
-// setup initializes map fields and a new game of the server instance.
-func (s *Server) setup(settings *menu.ServerSettings) {
+// Close stops the simulation and finishes the match recording
+// writing all the recorded frames.
+// The connected clients are not disconnected.
+func (s *Server) Close() {
+	s.closeOnce.Do(func() {
+		close(s.done)
+		s.running.Wait()
+
+		// write the pending frames and finish the recording
+		if s.recording != nil {
+			close(s.frames)
+			<-s.recording.written
+			if err := s.recording.recorder.Close(); err != nil {
+				logger.Warn("failed to finish the match recording: ", err)
+			}
+		}
+	})
+}
+
+// routes creates a router with the server's handlers.
+//
+// Returns the created router.
+func (s *Server) routes() http.Handler {
+	// create and init a new router instance
+	r := chi.NewRouter()
+
+	// add handlers
+	r.Post(CreatePlayerPostfix, s.createPlayerHandler)
+	r.Get(ConnectPlayerPostfix+"{id}", s.connectPlayerHandler)
+	r.Get(StatusPostfix, s.statusHandler)
+
+	return r
+}
+
+// start runs the simulation goroutine.
+func (s *Server) start() {
+	s.running.Add(1)
+	go func() {
+		defer s.running.Done()
+		s.simulate()
+	}()
+}
+
+// Certificate returns the server's certificate encoded to PEM.
+// Clients can trust it to connect to the server with
+// a self-signed certificate.
+//
+// Returns nil if tls is disabled.
+func (s *Server) Certificate() []byte {
+	return s.certificate
+}
+
+// setup initializes map fields, channels, a new game and
+// the tls configuration of the server instance.
+//
+// Returns an error if the tls can't be set up, otherwise nil.
+func (s *Server) setup() error {
 	// init the map with updates
 	s.playerUpdates = make(map[int64]*model.PlayerUpdateMessage)
 
+	// init the map with pings
+	s.playerPings = make(map[int64]uint16)
+
+	// init the map with visible squares
+	s.visibleSquares = make(map[int64]map[int64]bool)
+
+	// init the map with clients
+	s.clients = make(map[int64]*client)
+
+	// init the channels
+	s.inputs = make(chan playerInput, inputsBufferSize)
+	s.pings = make(chan playerPing, inputsBufferSize)
+	s.joins = make(chan chan *joinResult)
+	s.leaves = make(chan int64)
+	s.frames = make(chan *replay.Frame, framesBufferSize)
+	s.done = make(chan struct{})
+
+	// set up tls
+	if err := s.setupTLS(); err != nil {
+		return err
+	}
+
 	// inits a new game
-	s.InitServerGame(settings)
+	s.InitServerGame(s.settings)
+	s.startedAt = s.clock.Now()
+	logger.Info("game seed: ", s.Seed)
+
+	// start recording the match
+	s.setupRecording()
+
+	// publish the initial status
+	s.publishStatus()
+
+	return nil
+}
+
+// setupTLS creates the server's tls configuration
+// depending on the tls mode from the settings.
+// In the self-signed mode the generated certificate
+// is saved to the file so remote clients can trust it.
+//
+// Returns an error if the configuration can't be created, otherwise nil.
+func (s *Server) setupTLS() error {
+	// create the configuration
+	tlsConfig, certificate, err := tlsutil.ServerConfig(
+		s.settings.TLSMode,
+		config.TLSCertFile(),
+		config.TLSKeyFile(),
+		s.hosts(),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to set up tls: %w", err)
+	}
+	s.tlsConfig = tlsConfig
+	s.certificate = certificate
+
+	// share the self-signed certificate
+	if s.settings.TLSMode == tlsutil.ModeSelfSigned {
+		if err = os.WriteFile(selfSignedCertFile, certificate, 0644); err != nil {
+			logger.Warn("failed to save the self-signed certificate: ", err)
+		} else {
+			logger.Info("self-signed certificate is saved to ", selfSignedCertFile)
+		}
+	}
+
+	return nil
+}
+
+// hosts returns the hosts the server can be reached by.
+// If the server is public the addresses of all
+// network interfaces are included.
+func (s *Server) hosts() []string {
+	hosts := []string{"localhost", "127.0.0.1", "::1"}
+	if !s.settings.IsPublic {
+		return hosts
+	}
+
+	// add the addresses of the network interfaces
+	addrs, err := net.InterfaceAddrs()
+	if err != nil {
+		logger.Warn("failed to get network interface addresses: ", err)
+		return hosts
+	}
+	for _, addr := range addrs {
+		if ipNet, ok := addr.(*net.IPNet); ok && !ipNet.IP.IsLoopback() {
+			hosts = append(hosts, ipNet.IP.String())
+		}
+	}
+
+	return hosts
 }
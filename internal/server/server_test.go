@@ -0,0 +1,284 @@
+package server
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/gorilla/websocket"
+	"math/rand"
+	"net/http"
+	"net/http/httptest"
+	"online_shooter/internal/config/configtest"
+	"online_shooter/internal/game/arena"
+	"online_shooter/internal/game/game"
+	"online_shooter/internal/game/geometry"
+	"online_shooter/internal/model"
+	"online_shooter/internal/tlsutil"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestMain(m *testing.M) {
+	configtest.Main(m)
+}
+
+// startTestServer starts a server with the accepted amount
+// of squares and an http server serving its routes.
+func startTestServer(t *testing.T, playerCount int) (*Server, *httptest.Server) {
+	t.Helper()
+
+	s, err := NewServer(&game.ServerSettings{
+		PlayerCount:   playerCount,
+		ObstacleLevel: arena.MediumObstaclesAmount,
+		TLSMode:       tlsutil.ModeOff,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	s.start()
+	ts := httpTestServer(t, s)
+
+	return s, ts
+}
+
+// httpTestServer starts an http server serving
+// the server's routes and closes both when the test ends.
+func httpTestServer(t *testing.T, s *Server) *httptest.Server {
+	t.Helper()
+
+	ts := httptest.NewServer(s.routes())
+	t.Cleanup(func() {
+		ts.Close()
+		s.Close()
+	})
+
+	return ts
+}
+
+// createPlayer creates a player on the test server.
+//
+// Returns the created player's id and the response status code.
+func createPlayer(t *testing.T, ts *httptest.Server) (int64, int) {
+	t.Helper()
+
+	resp, err := http.Post(ts.URL+CreatePlayerPostfix, "", nil)
+	if err != nil {
+		t.Errorf("create player: %v", err)
+		return 0, 0
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusCreated {
+		return 0, resp.StatusCode
+	}
+
+	var response model.CreatePlayerResponse
+	if err = json.NewDecoder(resp.Body).Decode(&response); err != nil {
+		t.Errorf("decode create player response: %v", err)
+		return 0, 0
+	}
+
+	return response.Player.Id, resp.StatusCode
+}
+
+// connectPlayer establishes a websocket connection for the player.
+func connectPlayer(ts *httptest.Server, id int64) (*websocket.Conn, error) {
+	url := fmt.Sprintf("ws%s%s%d", strings.TrimPrefix(ts.URL, "http"), ConnectPlayerPostfix, id)
+	conn, _, err := websocket.DefaultDialer.Dial(url, nil)
+	return conn, err
+}
+
+// getStatus requests the server status.
+func getStatus(t *testing.T, ts *httptest.Server) *model.StatusResponse {
+	t.Helper()
+
+	resp, err := http.Get(ts.URL + StatusPostfix)
+	if err != nil {
+		t.Errorf("get status: %v", err)
+		return nil
+	}
+	defer resp.Body.Close()
+
+	var status model.StatusResponse
+	if err = json.NewDecoder(resp.Body).Decode(&status); err != nil {
+		t.Errorf("decode status: %v", err)
+		return nil
+	}
+
+	return &status
+}
+
+func TestCreatePlayerWhenFull(t *testing.T) {
+	_, ts := startTestServer(t, 1)
+
+	if _, code := createPlayer(t, ts); code != http.StatusCreated {
+		t.Fatalf("first player: got status %d, want %d", code, http.StatusCreated)
+	}
+	if _, code := createPlayer(t, ts); code != http.StatusServiceUnavailable {
+		t.Fatalf("second player: got status %d, want %d", code, http.StatusServiceUnavailable)
+	}
+}
+
+func TestNewServerWithoutCertificate(t *testing.T) {
+	s, err := NewServer(&game.ServerSettings{
+		PlayerCount:   1,
+		ObstacleLevel: arena.LowObstaclesAmount,
+		TLSMode:       tlsutil.ModeCertificate,
+	})
+	if err == nil || s != nil {
+		t.Fatalf("got server %v and error %v, want an error without the certificate files", s, err)
+	}
+}
+
+func TestConnectUnknownPlayer(t *testing.T) {
+	_, ts := startTestServer(t, 1)
+
+	resp, err := http.Get(fmt.Sprintf("%s%s%d", ts.URL, ConnectPlayerPostfix, 42))
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+
+	if resp.StatusCode != http.StatusNotFound {
+		t.Fatalf("got status %d, want %d", resp.StatusCode, http.StatusNotFound)
+	}
+}
+
+// TestLoad connects more clients than the server has places for,
+// makes them send inputs, reconnect and request the status
+// concurrently. It is meant to be run with the race detector.
+func TestLoad(t *testing.T) {
+	const (
+		playerCount = 8
+		clients     = 12
+		sessions    = 3
+		inputs      = 40
+	)
+
+	_, ts := startTestServer(t, playerCount)
+
+	var updates, rejected atomic.Int64
+	stop := make(chan struct{})
+
+	// request the status while the clients play
+	var statusWg sync.WaitGroup
+	statusWg.Add(1)
+	go func() {
+		defer statusWg.Done()
+		for {
+			select {
+			case <-stop:
+				return
+			default:
+				getStatus(t, ts)
+				time.Sleep(5 * time.Millisecond)
+			}
+		}
+	}()
+
+	var wg sync.WaitGroup
+	for i := 0; i < clients; i++ {
+		wg.Add(1)
+		go func(seed int64) {
+			defer wg.Done()
+			r := rand.New(rand.NewSource(seed))
+
+			for session := 0; session < sessions; session++ {
+				id, code := createPlayer(t, ts)
+				if code == http.StatusServiceUnavailable {
+					rejected.Add(1)
+					time.Sleep(20 * time.Millisecond)
+					continue
+				}
+				if code != http.StatusCreated {
+					return
+				}
+
+				conn, err := connectPlayer(ts, id)
+				if err != nil {
+					t.Errorf("connect player: %v", err)
+					return
+				}
+
+				// read the updates till the connection is closed
+				done := make(chan struct{})
+				go func() {
+					defer close(done)
+					for {
+						_, msg, err := conn.ReadMessage()
+						if err != nil {
+							return
+						}
+						var update model.GameUpdateMessage
+						if err = json.Unmarshal(msg, &update); err != nil {
+							t.Errorf("decode game update: %v", err)
+							return
+						}
+						updates.Add(1)
+					}
+				}()
+
+				// send random inputs
+				for j := 0; j < inputs; j++ {
+					msg, _ := json.Marshal(&model.PlayerUpdateMessage{
+						LeftKeyPressed:  r.Intn(2) == 0,
+						UpKeyPressed:    r.Intn(2) == 0,
+						RightKeyPressed: r.Intn(2) == 0,
+						DownKeyPressed:  r.Intn(2) == 0,
+						Shot:            r.Intn(2) == 0,
+						Aim: geometry.Point{
+							X: r.Float32() * 2000,
+							Y: r.Float32() * 2000,
+						},
+					})
+					if err = conn.WriteMessage(websocket.TextMessage, msg); err != nil {
+						t.Errorf("send input: %v", err)
+						break
+					}
+					time.Sleep(5 * time.Millisecond)
+				}
+
+				conn.WriteMessage(websocket.CloseMessage,
+					websocket.FormatCloseMessage(websocket.CloseNormalClosure, ""))
+				conn.Close()
+				<-done
+			}
+		}(int64(i))
+	}
+
+	wg.Wait()
+	close(stop)
+	statusWg.Wait()
+
+	if updates.Load() == 0 {
+		t.Error("clients got no game updates")
+	}
+	if rejected.Load() == 0 {
+		t.Error("no client was rejected although there are more clients than places")
+	}
+
+	// every player should be replaced with a bot after leaving
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		status := getStatus(t, ts)
+		if status == nil {
+			return
+		}
+
+		bots := 0
+		for _, player := range status.Players {
+			if player.IsBot {
+				bots++
+			}
+		}
+		if len(status.Players) == playerCount && bots == playerCount {
+			return
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("got %d squares with %d bots, want %d bots", len(status.Players), bots, playerCount)
+		}
+		time.Sleep(50 * time.Millisecond)
+	}
+}
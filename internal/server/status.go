@@ -0,0 +1,78 @@
+package server
+
+import (
+	"encoding/json"
+	"net/http"
+	"online_shooter/internal/logger"
+	"online_shooter/internal/model"
+	"sort"
+)
+
+// statusHandler handles an http request for the server status
+// responding with the seed of the game, the list of
+// the squares in the game and their stats including the players' ping
+// and the state of the match.
+// The status is published by the simulation
+// together with the scoreboard.
+func (s *Server) statusHandler(w http.ResponseWriter, r *http.Request) {
+	response := s.status.Load()
+
+	// encode the response
+	w.Header().Set("Content-Type", "application/json")
+	err := json.NewEncoder(w).Encode(response)
+	if err != nil {
+		http.Error(w, "failed to encode the response", http.StatusInternalServerError)
+		logger.Warn("error while encoding status response: ", err)
+		return
+	}
+}
+
+// publishStatus stores the current server status
+// to be read by the status handler.
+func (s *Server) publishStatus() {
+	s.status.Store(&model.StatusResponse{
+		Seed:    s.Seed,
+		Players: s.scoreboard(),
+		Match:   s.MatchStatus(),
+	})
+}
+
+// scoreboard collects the stats of every square in the game
+// including the stats of their fights.
+//
+// Returns the stats grouped by the teams
+// and sorted by kills and then by deaths.
+func (s *Server) scoreboard() []model.PlayerStatus {
+	players := make([]model.PlayerStatus, 0, len(s.Squares))
+	for _, square := range s.Squares {
+		players = append(players, model.PlayerStatus{
+			Id:     square.Id,
+			IsBot:  square.IsBot,
+			Team:   square.Team,
+			Kills:  square.Kills,
+			Deaths: square.Deaths,
+			Ping:   square.Ping,
+			Color:  square.Color,
+
+			Assists:     square.Combat.Assists,
+			DamageDealt: square.Combat.DamageDealt,
+			DamageTaken: square.Combat.DamageTaken,
+			Streak:      square.Combat.Streak,
+			BestStreak:  square.Combat.BestStreak,
+			Accuracy:    square.Combat.Accuracy(),
+		})
+	}
+
+	// sort the players by teams, kills and then by deaths
+	sort.Slice(players, func(i, j int) bool {
+		if players[i].Team != players[j].Team {
+			return players[i].Team < players[j].Team
+		}
+		if players[i].Kills != players[j].Kills {
+			return players[i].Kills > players[j].Kills
+		}
+		return players[i].Deaths < players[j].Deaths
+	})
+
+	return players
+}
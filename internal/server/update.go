@@ -1,20 +1,96 @@
 package server
 
 import (
+	"online_shooter/internal/game/arena"
 	"online_shooter/internal/game/entity"
-	"online_shooter/internal/game/geometry"
+	"online_shooter/internal/game/game"
 	"online_shooter/internal/logger"
 	"online_shooter/internal/model"
+	"online_shooter/internal/replay"
 	"time"
 )
 
-const framesPerSecond = 60
-const refreshingRate = 1000 / framesPerSecond
+const (
+	// maxStepsPerUpdate limits the amount of ticks the server
+	// simulates to catch up with the real time after a delay
+	maxStepsPerUpdate = 5
+
+	// inputsBufferSize is a size of the buffers
+	// for the players' inputs and pings
+	inputsBufferSize = 256
+
+	// framesBufferSize is an amount of the frames
+	// of the recorded match which can wait for writing
+	framesBufferSize = 64
+)
+
+// playerInput is an update message from the player.
+type playerInput struct {
+	id  int64
+	msg *model.PlayerUpdateMessage
+}
+
+// playerPing is a measured round trip time of the player.
+type playerPing struct {
+	id   int64
+	ping uint16
+}
+
+// joinResult contains copies of the player and the arena
+// created for a new player. Both are nil if there is
+// no space for the player.
+type joinResult struct {
+	player *entity.Square
+	arena  *arena.Arena
+}
+
+// simulate runs the simulation loop. It is the only
+// goroutine which changes the game state: it applies
+// players' inputs, pings, joins and leaves received
+// from the channels and updates the game every tick.
+// The loop ends when the server is closed.
+func (s *Server) simulate() {
+	// set refreshing time for the ticker
+	ticker := time.NewTicker(game.TickDuration)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-s.done:
+			// record the ticks after the last frame
+			s.recordFrame(true)
+			return
+
+		case input := <-s.inputs:
+			s.storeInput(input)
+
+		// store the player's ping till the next tick
+		case ping := <-s.pings:
+			if _, ok := s.Squares[ping.id]; ok {
+				s.playerPings[ping.id] = ping.ping
+			}
+
+		case reply := <-s.joins:
+			reply <- s.addPlayer()
+
+		case id := <-s.leaves:
+			s.removePlayer(id)
+
+		// every tick updates a game state and publishes it
+		case <-ticker.C:
+			s.Update()
+			s.publish()
+		}
+	}
+}
 
 // Update updates a server's game state using
 // the data about the players from the clients.
+// The game is stepped by the ticks of the constant duration
+// which have elapsed since the previous update, so the match
+// doesn't depend on the timer precision and can be simulated again.
 func (s *Server) Update() {
-	now := time.Now()
+	now := s.clock.Now()
 
 	// set the last update time if it doesn't exist
 	if s.lastUpdate == nil {
@@ -22,134 +98,94 @@ func (s *Server) Update() {
 		return
 	}
 
-	// count the speed correction value
-	deltaTime := float32(now.Sub(*s.lastUpdate).Seconds())
+	// count the elapsed time
+	s.lag += now.Sub(*s.lastUpdate)
 	s.lastUpdate = &now
 
-	// go through every square in the game and update its state
-	for _, square := range s.Squares {
-		if square.IsBot {
-			// change game's state for the bot
-			enemy, distance := s.FindEnemy(square)
-			square.Move(s.CountMovingVector(square, enemy, distance), deltaTime)
-			aim := s.CountShootingPoint(enemy, distance)
-			if aim != nil {
-				square.Shoot(*aim)
-			}
-		} else {
-			// change game's state for the player
-			if s.playerUpdates[square.Id] != nil {
-				updatePlayer(square, s.playerUpdates[square.Id], deltaTime)
-				s.playerUpdates[square.Id] = nil
-			}
+	// update the players' ping
+	for id, ping := range s.playerPings {
+		s.Squares[id].Ping = ping
+	}
+
+	// update the game using the players' updates
+	for steps := 0; s.lag >= game.TickDuration; steps++ {
+		// skip the time the server can't catch up with
+		if steps == maxStepsPerUpdate {
+			s.lag = 0
+			break
 		}
 
-		s.CheckSquareCollision(square)
-		square.UpdateBullets(deltaTime)
-		s.CheckBulletsCollision(square)
+		s.Step(s.playerUpdates, game.TickDuration)
+		s.kills = append(s.kills, s.KillEvents()...)
+		s.tick++
+		s.lag -= game.TickDuration
+
+		// every update is applied only once
+		clear(s.playerUpdates)
 	}
 }
 
-// updatePlayer updates the player's square state
-// using the information from the client.
+// storeInput stores the player's update till the next tick
+// merging it with the update received before the same tick.
 //
-// Accepts a pointer to the player instance,
-// a pointer to the instance with a square's state update
-// and a delta time value to correct the player's square speed.
-func updatePlayer(player *entity.Square, upd *model.PlayerUpdateMessage, deltaTime float32) {
-	// change player's position
-	vector := &geometry.Vector{}
-	if upd.UpKeyPressed {
-		vector.Y--
-	}
-	if upd.DownKeyPressed {
-		vector.Y++
-	}
-	if upd.LeftKeyPressed {
-		vector.X--
-	}
-	if upd.RightKeyPressed {
-		vector.X++
+// Accepts the player's input.
+func (s *Server) storeInput(input playerInput) {
+	if _, ok := s.Squares[input.id]; !ok {
+		return
 	}
 
-	vector.Normalize()
-	player.Move(*vector, deltaTime)
-
-	// make player shoot
-	if upd.Shot {
-		player.Shoot(upd.Aim)
-	} else {
-		player.Lock()
-		player.CanShoot = true
-		player.Unlock()
-	}
+	s.playerUpdates[input.id] = game.MergeInput(s.playerUpdates[input.id], input.msg)
+	s.recordInput(input.id, input.msg)
 }
 
 // addPlayer adds a new player to the game swapping
 // it with the bot. If there are no bots in the game
 // logs it and doesn't add the player.
 //
-// Accepts a pointer to the player that should be added.
-func (s *Server) addPlayer(player *entity.Square) {
-	s.serverMutex.Lock()
-
-	// find the weakest bot to remove it from the game
-	bot := s.FindWeakestBot()
-	if bot != nil {
-		// if there is a bot in the game
-		// generate an id
-		player.Id = s.GenerateUniqueId()
-
-		// transfer the bot's spawn point to the player
-		player.Spawn = bot.Spawn
-
-		// set the player's position to its spawn point
-		player.Position = player.Spawn
-
-		// delete the bot
-		close(s.Squares[bot.Id].ShotCh)
-		delete(s.Squares, bot.Id)
-
-		// add the player to the game
-		s.Squares[player.Id] = player
-	} else {
+// Returns a pointer to the result containing copies
+// of the added player and the arena.
+func (s *Server) addPlayer() *joinResult {
+	player := s.AddPlayer()
+	if player == nil {
 		// if there is no bot in the game
 		// log it
 		logger.Info("there is no space for a new player")
-
-		// close connection with the player that wanted to connect
-		player.Conn.Close()
+		return &joinResult{}
 	}
 
-	s.serverMutex.Unlock()
+	// register the player's client waiting for the connection
+	s.clientsMutex.Lock()
+	s.clients[player.Id] = newClient()
+	s.clientsMutex.Unlock()
+
+	s.recordEvent(replay.EventJoin, player.Id)
+
+	return &joinResult{
+		player: player.Clone(),
+		arena:  s.Arena.Clone(),
+	}
 }
 
 // removePlayer removes a player with accepted id
-// from the game.
+// from the game replacing it with a new bot.
 //
 // Accepts an id of the player that should be removed.
 func (s *Server) removePlayer(id int64) {
-	s.serverMutex.Lock()
-	// close player connection
-	s.Squares[id].Conn.Close()
-
-	// save player's spawn point
-	spawn := s.Squares[id].Spawn
-
-	// delete player
-	close(s.Squares[id].ShotCh)
-	delete(s.Squares, id)
-
-	// generate new bot id
-	id = s.GenerateUniqueId()
+	if !s.RemovePlayer(id) {
+		return
+	}
 
-	// create and init a new bot instance
-	bot := entity.NewBot(id)
+	// forget the player's client and stop writing to it
+	s.clientsMutex.Lock()
+	if c := s.clients[id]; c != nil {
+		close(c.removed)
+	}
+	delete(s.clients, id)
+	s.clientsMutex.Unlock()
 
-	// transfer the deleted player's spawn point to the bot
-	bot.Spawn = spawn
+	delete(s.playerUpdates, id)
+	delete(s.playerPings, id)
+	delete(s.visibleSquares, id)
 
-	// add the created bot to the game
-	s.Squares[id] = bot
-	s.serverMutex.Unlock()
+	s.recordEvent(replay.EventLeave, id)
 }
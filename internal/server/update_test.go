@@ -0,0 +1,77 @@
+package server
+
+import (
+	"online_shooter/internal/game/arena"
+	"online_shooter/internal/game/clock"
+	"online_shooter/internal/game/entity"
+	"online_shooter/internal/game/game"
+	"online_shooter/internal/model"
+	"online_shooter/internal/tlsutil"
+	"testing"
+	"time"
+)
+
+// newInputServer creates a server with a player
+// and a manual clock which is ready for the first tick.
+//
+// Returns pointers to the server, the clock and the player's square.
+func newInputServer(t *testing.T) (*Server, *clock.Manual, *entity.Square) {
+	t.Helper()
+
+	s, err := NewServer(&game.ServerSettings{
+		PlayerCount:   2,
+		ObstacleLevel: arena.LowObstaclesAmount,
+		TLSMode:       tlsutil.ModeOff,
+		Seed:          1,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	c := clock.NewManual(time.Unix(0, 0))
+	s.clock = c
+	player := s.AddPlayer()
+	s.Update()
+
+	return s, c, player
+}
+
+// stepWithInputs sends the player's inputs before
+// the same tick and simulates the tick.
+func stepWithInputs(s *Server, c *clock.Manual, id int64, inputs ...*model.PlayerUpdateMessage) {
+	for _, input := range inputs {
+		s.storeInput(playerInput{id: id, msg: input})
+	}
+	c.Advance(game.TickDuration)
+	s.Update()
+}
+
+func TestWeaponSwitchIsKeptTillTick(t *testing.T) {
+	s, c, player := newInputServer(t)
+
+	stepWithInputs(s, c, player.Id, &model.PlayerUpdateMessage{Weapon: int(entity.Shotgun) + 1}, &model.PlayerUpdateMessage{})
+	if player.WeaponKind != entity.Shotgun {
+		t.Fatalf("got weapon %d, want the switch of the earlier input applied", player.WeaponKind)
+	}
+}
+
+func TestReloadIsKeptTillTick(t *testing.T) {
+	s, c, player := newInputServer(t)
+	player.Ammo.Magazine--
+
+	stepWithInputs(s, c, player.Id, &model.PlayerUpdateMessage{Reload: true}, &model.PlayerUpdateMessage{})
+	if !player.Reloading() {
+		t.Fatal("the reload of the earlier input is lost")
+	}
+}
+
+func TestDashIsKeptTillTick(t *testing.T) {
+	s, c, player := newInputServer(t)
+
+	stepWithInputs(s, c, player.Id,
+		&model.PlayerUpdateMessage{Dash: true, RightKeyPressed: true},
+		&model.PlayerUpdateMessage{RightKeyPressed: true},
+	)
+	if !player.Dashing() {
+		t.Fatal("the dash of the earlier input is lost")
+	}
+}
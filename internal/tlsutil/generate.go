@@ -0,0 +1,166 @@
+package tlsutil
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"net"
+	"time"
+)
+
+const (
+	organization  = "online_shooter"
+	validDuration = 365 * 24 * time.Hour
+)
+
+type CA struct {
+	Certificate *x509.Certificate
+	CertPEM     []byte
+	key         *ecdsa.PrivateKey
+}
+
+// NewCA generates a new certificate authority
+// which can issue certificates for the servers.
+//
+// Returns a pointer to the created authority and
+// an error if it exists, otherwise nil.
+func NewCA() (*CA, error) {
+	// generate the authority key
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return nil, err
+	}
+
+	// create a template of the authority certificate
+	template, err := newTemplate()
+	if err != nil {
+		return nil, err
+	}
+	template.Subject.CommonName = organization + " CA"
+	template.IsCA = true
+	template.KeyUsage = x509.KeyUsageCertSign | x509.KeyUsageDigitalSignature
+
+	// sign the certificate by itself
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		return nil, err
+	}
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		return nil, err
+	}
+
+	return &CA{
+		Certificate: cert,
+		CertPEM:     pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}),
+		key:         key,
+	}, nil
+}
+
+// Issue issues a server certificate signed by the authority.
+//
+// Accepts hosts the certificate is valid for.
+//
+// Returns the certificate, the certificate encoded to PEM
+// and an error if it exists, otherwise nil.
+func (ca *CA) Issue(hosts []string) (tls.Certificate, []byte, error) {
+	return issue(hosts, ca.Certificate, ca.key)
+}
+
+// SelfSigned generates a self-signed server certificate.
+//
+// Accepts hosts the certificate is valid for.
+//
+// Returns the certificate, the certificate encoded to PEM
+// and an error if it exists, otherwise nil.
+func SelfSigned(hosts []string) (tls.Certificate, []byte, error) {
+	return issue(hosts, nil, nil)
+}
+
+// issue generates a server certificate for the hosts.
+// If there is no parent certificate the generated
+// certificate is signed by itself.
+//
+// Accepts hosts, a pointer to the parent certificate
+// and a pointer to the parent key.
+//
+// Returns the certificate, the certificate encoded to PEM
+// and an error if it exists, otherwise nil.
+func issue(hosts []string, parent *x509.Certificate, parentKey *ecdsa.PrivateKey) (tls.Certificate, []byte, error) {
+	// generate the server key
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return tls.Certificate{}, nil, err
+	}
+
+	// create a template of the server certificate
+	template, err := newTemplate()
+	if err != nil {
+		return tls.Certificate{}, nil, err
+	}
+	template.KeyUsage = x509.KeyUsageDigitalSignature
+	template.ExtKeyUsage = []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth}
+
+	// fill hosts of the certificate
+	for _, h := range hosts {
+		if ip := net.ParseIP(h); ip != nil {
+			template.IPAddresses = append(template.IPAddresses, ip)
+		} else {
+			template.DNSNames = append(template.DNSNames, h)
+		}
+	}
+	if len(hosts) > 0 {
+		template.Subject.CommonName = hosts[0]
+	}
+
+	// sign the certificate by itself if there is no parent
+	if parent == nil {
+		parent = template
+		parentKey = key
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, parent, &key.PublicKey, parentKey)
+	if err != nil {
+		return tls.Certificate{}, nil, err
+	}
+
+	// encode the key pair to PEM
+	keyDer, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		return tls.Certificate{}, nil, err
+	}
+	certPEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})
+	keyPEM := pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDer})
+
+	cert, err := tls.X509KeyPair(certPEM, keyPEM)
+	if err != nil {
+		return tls.Certificate{}, nil, err
+	}
+
+	return cert, certPEM, nil
+}
+
+// newTemplate creates a certificate template
+// with a random serial number and validity period.
+//
+// Returns a pointer to the template and an error
+// if it exists, otherwise nil.
+func newTemplate() (*x509.Certificate, error) {
+	serial, err := rand.Int(rand.Reader, new(big.Int).Lsh(big.NewInt(1), 128))
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	return &x509.Certificate{
+		SerialNumber:          serial,
+		Subject:               pkix.Name{Organization: []string{organization}},
+		NotBefore:             now.Add(-time.Hour),
+		NotAfter:              now.Add(validDuration),
+		BasicConstraintsValid: true,
+	}, nil
+}
@@ -0,0 +1,109 @@
+package tlsutil
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"os"
+)
+
+const (
+	ModeOff         = "off"
+	ModeSelfSigned  = "self-signed"
+	ModeCertificate = "certificate"
+)
+
+// ServerConfig creates a tls configuration for the server.
+//
+// Accepts a tls mode, paths to the certificate and key files
+// which are used in the certificate mode and hosts which are
+// used to generate a certificate in the self-signed mode.
+//
+// Returns a pointer to the tls configuration, the certificate
+// of the server encoded to PEM and an error if it exists,
+// otherwise nil. If the mode is off returns nil configuration.
+func ServerConfig(mode, certFile, keyFile string, hosts []string) (*tls.Config, []byte, error) {
+	var cert tls.Certificate
+	var certPEM []byte
+	var err error
+
+	switch mode {
+	// if tls is disabled
+	case ModeOff, "":
+		return nil, nil, nil
+
+	// if the certificate is provided by files
+	case ModeCertificate:
+		if certFile == "" || keyFile == "" {
+			return nil, nil, errors.New("certificate and key files are required in the certificate mode")
+		}
+
+		// read the certificate to share it
+		certPEM, err = os.ReadFile(certFile)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		// load the key pair
+		cert, err = tls.LoadX509KeyPair(certFile, keyFile)
+		if err != nil {
+			return nil, nil, err
+		}
+
+	// if the certificate should be generated
+	case ModeSelfSigned:
+		cert, certPEM, err = SelfSigned(hosts)
+		if err != nil {
+			return nil, nil, err
+		}
+
+	default:
+		return nil, nil, fmt.Errorf("unknown tls mode: %s", mode)
+	}
+
+	return &tls.Config{
+		Certificates: []tls.Certificate{cert},
+		MinVersion:   tls.VersionTLS12,
+	}, certPEM, nil
+}
+
+// ClientConfig creates a tls configuration for the client.
+//
+// Accepts a path to the file with trusted certificates
+// encoded to PEM, a flag that disables certificate verification
+// and additional trusted certificates encoded to PEM.
+//
+// Returns a pointer to the tls configuration and an error
+// if it exists, otherwise nil.
+func ClientConfig(caFile string, insecureSkipVerify bool, trusted ...[]byte) (*tls.Config, error) {
+	// start with the system certificates
+	pool, err := x509.SystemCertPool()
+	if err != nil {
+		pool = x509.NewCertPool()
+	}
+
+	// add certificates from the file
+	if caFile != "" {
+		caPEM, err := os.ReadFile(caFile)
+		if err != nil {
+			return nil, err
+		}
+		if !pool.AppendCertsFromPEM(caPEM) {
+			return nil, fmt.Errorf("no certificates found in %s", caFile)
+		}
+	}
+
+	// add additional certificates
+	for _, certPEM := range trusted {
+		if len(certPEM) > 0 {
+			pool.AppendCertsFromPEM(certPEM)
+		}
+	}
+
+	return &tls.Config{
+		RootCAs:            pool,
+		InsecureSkipVerify: insecureSkipVerify,
+		MinVersion:         tls.VersionTLS12,
+	}, nil
+}
@@ -0,0 +1,112 @@
+package tlsutil
+
+import (
+	"crypto/tls"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// newTestServer starts an https server using
+// a certificate issued by a locally generated authority.
+func newTestServer(t *testing.T) (*httptest.Server, *CA) {
+	t.Helper()
+
+	ca, err := NewCA()
+	if err != nil {
+		t.Fatal(err)
+	}
+	cert, _, err := ca.Issue([]string{"127.0.0.1", "localhost"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	srv := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	srv.TLS = &tls.Config{Certificates: []tls.Certificate{cert}}
+	srv.StartTLS()
+	t.Cleanup(srv.Close)
+
+	return srv, ca
+}
+
+// get makes a request to the url using the tls configuration.
+func get(url string, config *tls.Config) error {
+	client := &http.Client{Transport: &http.Transport{TLSClientConfig: config}}
+	resp, err := client.Get(url)
+	if err != nil {
+		return err
+	}
+	return resp.Body.Close()
+}
+
+func TestClientConfigTrustsCAFile(t *testing.T) {
+	srv, ca := newTestServer(t)
+
+	caFile := filepath.Join(t.TempDir(), "ca.pem")
+	if err := os.WriteFile(caFile, ca.CertPEM, 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	config, err := ClientConfig(caFile, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = get(srv.URL, config); err != nil {
+		t.Fatalf("expected the certificate to be trusted: %v", err)
+	}
+}
+
+func TestClientConfigRejectsUnknownAuthority(t *testing.T) {
+	srv, _ := newTestServer(t)
+
+	config, err := ClientConfig("", false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = get(srv.URL, config); err == nil {
+		t.Fatal("expected the certificate to be rejected")
+	}
+}
+
+func TestClientConfigSkipsVerification(t *testing.T) {
+	srv, _ := newTestServer(t)
+
+	config, err := ClientConfig("", true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = get(srv.URL, config); err != nil {
+		t.Fatalf("expected the verification to be skipped: %v", err)
+	}
+}
+
+func TestServerConfigSelfSigned(t *testing.T) {
+	serverConfig, certPEM, err := ServerConfig(ModeSelfSigned, "", "", []string{"127.0.0.1"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	srv := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	srv.TLS = serverConfig
+	srv.StartTLS()
+	defer srv.Close()
+
+	config, err := ClientConfig("", false, certPEM)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = get(srv.URL, config); err != nil {
+		t.Fatalf("expected the self-signed certificate to be trusted: %v", err)
+	}
+}
+
+func TestServerConfigOff(t *testing.T) {
+	config, _, err := ServerConfig(ModeOff, "", "", nil)
+	if err != nil || config != nil {
+		t.Fatalf("expected no configuration, got %v, %v", config, err)
+	}
+}
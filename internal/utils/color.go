@@ -6,12 +6,14 @@ import (
 )
 
 // RandomBrightColor generates a random bright color.
-func RandomBrightColor() color.RGBA {
+//
+// Accepts a pointer to the random source.
+func RandomBrightColor(random *rand.Rand) color.RGBA {
 	var r, g, b uint8
 	for {
-		r = uint8(rand.Intn(256))
-		g = uint8(rand.Intn(256))
-		b = uint8(rand.Intn(256))
+		r = uint8(random.Intn(256))
+		g = uint8(random.Intn(256))
+		b = uint8(random.Intn(256))
 
 		// check if the color is bright enough
 		if (int(r) + int(g) + int(b)) > 382 { // 255 * 3 / 2 = 382.5
@@ -20,3 +22,16 @@ func RandomBrightColor() color.RGBA {
 	}
 	return color.RGBA{R: r, G: g, B: b, A: 255}
 }
+
+// TintedColor generates a random shade of the color,
+// so the objects of the same color can be told apart.
+//
+// Accepts the base color and a pointer to the random source.
+func TintedColor(base color.RGBA, random *rand.Rand) color.RGBA {
+	// scale the color's brightness from 70% to 130%
+	k := 0.7 + 0.6*random.Float64()
+	tint := func(c uint8) uint8 {
+		return uint8(min(float64(c)*k, 255))
+	}
+	return color.RGBA{R: tint(base.R), G: tint(base.G), B: tint(base.B), A: 255}
+}
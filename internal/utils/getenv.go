@@ -4,6 +4,7 @@ import (
 	"errors"
 	"fmt"
 	"os"
+	"strconv"
 )
 
 // GetIntEnvVar gets an integer variable from the
@@ -49,3 +50,41 @@ func GetFloatEnvVar(name string) (float32, error) {
 	}
 	return envInt, nil
 }
+
+// GetStringEnvVar gets a string variable from the
+// process environment.
+//
+// Accepts a name of the variable.
+//
+// Returns the variable's value and an error if it exists,
+// otherwise nil.
+func GetStringEnvVar(name string) (string, error) {
+	// get the var from the environment
+	envStr := os.Getenv(name)
+	if len(envStr) == 0 {
+		return "", errors.New(fmt.Sprintf("failed to get env variable: %s", name))
+	}
+	return envStr, nil
+}
+
+// GetBoolEnvVar gets a bool variable from the
+// process environment.
+//
+// Accepts a name of the variable.
+//
+// Returns the variable's value and an error if it exists,
+// otherwise nil.
+func GetBoolEnvVar(name string) (bool, error) {
+	// get the var from the environment
+	envStr := os.Getenv(name)
+	if len(envStr) == 0 {
+		return false, errors.New(fmt.Sprintf("failed to get env variable: %s", name))
+	}
+
+	// parse string to bool
+	envBool, err := strconv.ParseBool(envStr)
+	if err != nil {
+		return false, errors.New(fmt.Sprintf("failed to parse env variable: %s", name))
+	}
+	return envBool, nil
+}